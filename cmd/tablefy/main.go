@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/pflag"
 	"tablefy/internal/app"
@@ -19,6 +20,31 @@ var CommitHash = "unknown"
 func main() {
 	version := pflag.BoolP("version", "v", false, "Show version information")
 	autoExpand := pflag.BoolP("auto-expand", "a", false, "Auto-expand focused column if it contains truncated cells")
+	heatmap := pflag.Bool("heatmap", false, "Start with heatmap coloring of numeric columns enabled")
+	themeName := pflag.String("theme", "", "Color theme: dark, light, high-contrast or monochrome (default dark, monochrome when NO_COLOR is set)")
+	themeFile := pflag.String("theme-file", "", "Path to a JSON theme file (default $XDG_CONFIG_HOME/tablefy/theme.json if present)")
+	border := pflag.String("border", "normal", "Border style: normal, rounded, thick, double, ascii, underline or compact")
+	keepColors := pflag.Bool("keep-colors", false, "Render cells with the ANSI colors found in the input")
+	format := pflag.String("format", "", "Input format: auto, text, csv or json (default: text for stdin and watched commands, by extension for files)")
+	exportColors := pflag.String("export-colors", "strip", "ANSI colors in exported data: strip or preserve")
+	output := pflag.StringP("output", "o", "plain", "Export format used by o: plain, csv, tsv, json, ndjson, markdown or html")
+	tmpl := pflag.String("template", "", "Export each row through a Go template, e.g. '{{.NAMESPACE}}/{{.NAME}}' (replaces --output)")
+	exportHeader := pflag.Bool("export-header", false, "Include the header row in plain exports")
+	exportColumns := pflag.StringSlice("export-columns", nil, "Columns to export by header name, in this order, e.g. NAME,NAMESPACE (default: the visible columns)")
+	actions := pflag.StringArray("action", nil, "Row action [KEY:]NAME[confirm,pager]=COMMAND with {HEADER} placeholders, e.g. 'ctrl+d:describe[pager]=kubectl describe pod {NAME} -n {NAMESPACE}' (repeatable)")
+	actionsFile := pflag.String("actions-file", "", "Path to a JSON file of row actions (default $XDG_CONFIG_HOME/tablefy/actions.json if present)")
+	preview := pflag.String("preview", "", "Command previewing the row under the cursor in a side pane, with {HEADER} placeholders, e.g. 'kubectl logs --tail 20 {NAME}'")
+	previewPosition := pflag.String("preview-position", "bottom", "Where the preview pane goes: bottom or right")
+	openWith := pflag.String("open-with", "pager", "Program v and V open a cell or row in: pager ($PAGER) or editor ($VISUAL or $EDITOR)")
+	rowFormat := pflag.String("row-format", "yaml", "Format V opens the current row in: yaml or json")
+	watch := pflag.Duration("watch", 0, "Re-run the command given after -- at this interval (e.g. --watch 2s -- kubectl get pods)")
+	key := pflag.StringSlice("key", nil, "Columns identifying a row across refreshes or between diffed files, e.g. NAMESPACE,NAME (default: first column)")
+	highlightRefreshes := pflag.Int("highlight-refreshes", 3, "Number of refreshes a change stays highlighted in watch mode (0 disables highlighting)")
+	history := pflag.Int("history", 20, "Number of past snapshots kept in watch mode for stepping back with [ and ]")
+	stream := pflag.Bool("stream", false, "Start right away and append rows as they arrive on stdin (e.g. kubectl get pods -w)")
+	follow := pflag.Bool("follow", false, "Like --stream, keeping the view scrolled to the newest row")
+	joinOn := pflag.String("on", "", "join: key columns, NAME or LEFT=RIGHT (e.g. tablefy join nodes.txt pods.txt --on NAME=NODE)")
+	joinType := pflag.String("join-type", "inner", "join: inner, left or full")
 	pflag.Parse()
 
 	// Handle version flag
@@ -27,8 +53,77 @@ func main() {
 		os.Exit(0)
 	}
 
-	if err := app.Run(app.Config{AutoExpand: *autoExpand}); err != nil {
+	// "tablefy join A B --on KEY" joins two files and "tablefy diff A B" compares them instead of showing them
+	subcommand, args := splitSubcommand(*watch)
+	joinMode := subcommand == "join"
+
+	if joinMode && *joinOn == "" {
+		fmt.Fprintln(os.Stderr, "Error: join needs --on, e.g. tablefy join nodes.txt pods.txt --on NAME=NODE")
+		os.Exit(1)
+	}
+	if !joinMode && (pflag.CommandLine.Changed("on") || pflag.CommandLine.Changed("join-type")) {
+		fmt.Fprintln(os.Stderr, "Error: --on and --join-type are only used by tablefy join")
+		os.Exit(1)
+	}
+
+	if err := app.Run(app.Config{
+		AutoExpand:    *autoExpand,
+		Heatmap:       *heatmap,
+		Theme:         *themeName,
+		ThemeFile:     *themeFile,
+		Border:        *border,
+		KeepColors:    *keepColors,
+		ExportColors:  *exportColors,
+		Output:        *output,
+		ExportHeader:  *exportHeader,
+		Template:      *tmpl,
+		Actions:       *actions,
+		ActionsFile:   *actionsFile,
+		Preview:       *preview,
+		PreviewAt:     *previewPosition,
+		OpenWith:      *openWith,
+		RowFormat:     *rowFormat,
+		ExportColumns: *exportColumns,
+		Watch:         *watch,
+		Command:       commandArgs(*watch, args),
+		Files:         fileArgs(*watch, args),
+		Format:        *format,
+		JoinOn:        *joinOn,
+		JoinType:      *joinType,
+		Diff:          subcommand == "diff",
+		RowKey:        *key,
+		Highlight:     *highlightRefreshes,
+		History:       *history,
+		Stream:        *stream || *follow,
+		Follow:        *follow,
+	}); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// splitSubcommand returns the subcommand, the first argument when it is join or diff, and the other arguments
+// Flags may come before it; the arguments of a watched command are never a subcommand
+func splitSubcommand(watch time.Duration) (string, []string) {
+	args := pflag.Args()
+	if watch == 0 && len(args) > 0 && pflag.CommandLine.ArgsLenAtDash() != 0 && (args[0] == "join" || args[0] == "diff") {
+		return args[0], args[1:]
+	}
+	return "", args
+}
+
+// commandArgs returns the command to watch: the arguments, when --watch is set
+func commandArgs(watch time.Duration, args []string) []string {
+	if watch == 0 {
+		return nil
+	}
+	return args
+}
+
+// fileArgs returns the files to open: the arguments, unless they are a watched command
+func fileArgs(watch time.Duration, args []string) []string {
+	if watch > 0 {
+		return nil
+	}
+	return args
+}
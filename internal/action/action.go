@@ -0,0 +1,127 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"tablefy/internal/export"
+)
+
+// Action is a shell command run on table rows
+// {HEADER} placeholders in the command are replaced with the row's value in that column, quoted for the shell
+type Action struct {
+	Name    string `json:"name"`
+	Key     string `json:"key"`     // Key running the action directly, e.g. "ctrl+d"; empty for the menu only
+	Command string `json:"command"` // Shell command, run with sh -c
+	Confirm bool   `json:"confirm"` // Ask before running, for destructive commands
+	Pager   bool   `json:"pager"`   // Show the output in $PAGER instead of waiting for Enter
+}
+
+// placeholderPattern matches {NAME} placeholders; braces not naming a column are left alone, e.g. awk '{print $1}'
+var placeholderPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// Parse parses an action given on the command line: [KEY:]NAME[OPTIONS]=COMMAND
+// OPTIONS is a bracketed list of confirm and pager, e.g. "ctrl+d:describe[pager]=kubectl describe pod {NAME}"
+func Parse(spec string) (Action, error) {
+	head, command, ok := strings.Cut(spec, "=")
+	command = strings.TrimSpace(command)
+	if !ok || command == "" {
+		return Action{}, fmt.Errorf("invalid action %q (use [KEY:]NAME=COMMAND)", spec)
+	}
+
+	var a Action
+	if key, name, ok := strings.Cut(head, ":"); ok {
+		a.Key, head = strings.TrimSpace(key), name
+	}
+	if open := strings.Index(head, "["); open >= 0 && strings.HasSuffix(head, "]") {
+		for _, option := range strings.Split(head[open+1:len(head)-1], ",") {
+			switch strings.TrimSpace(option) {
+			case "confirm":
+				a.Confirm = true
+			case "pager":
+				a.Pager = true
+			default:
+				return Action{}, fmt.Errorf("invalid action %q: unknown option %q (use confirm or pager)", spec, option)
+			}
+		}
+		head = head[:open]
+	}
+
+	a.Name = strings.TrimSpace(head)
+	a.Command = command
+	if a.Name == "" {
+		return Action{}, fmt.Errorf("invalid action %q: missing name", spec)
+	}
+	return a, nil
+}
+
+// Load reads the actions defined in a JSON file: an array of {"name", "key", "command", "confirm", "pager"}
+// Without a file the user config file is read if it exists ($XDG_CONFIG_HOME/tablefy/actions.json on Linux)
+func Load(file string) ([]Action, error) {
+	if file == "" {
+		file = defaultFile()
+		if _, err := os.Stat(file); err != nil {
+			return nil, nil
+		}
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading actions file: %w", err)
+	}
+	var actions []Action
+	if err := json.Unmarshal(data, &actions); err != nil {
+		return nil, fmt.Errorf("error parsing actions file %s: %w", file, err)
+	}
+	for i, a := range actions {
+		if a.Name == "" || a.Command == "" {
+			return nil, fmt.Errorf("actions file %s: action %d needs a name and a command", file, i+1)
+		}
+	}
+	return actions, nil
+}
+
+// defaultFile returns the user actions location
+func defaultFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "tablefy", "actions.json")
+}
+
+// Expand fills the placeholders of the command with a row's values
+func (a Action) Expand(header, row []string) string {
+	return placeholderPattern.ReplaceAllStringFunc(a.Command, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		for i, h := range header {
+			if h == name {
+				if i < len(row) {
+					return export.ShellQuote(row[i])
+				}
+				return "''"
+			}
+		}
+		return placeholder
+	})
+}
+
+// Script returns the shell script running the command once per row, in order
+// Pager actions send the output of every run, errors included, to $PAGER (less by default);
+// the others wait for Enter so the output can be read before the table comes back
+func (a Action) Script(header []string, rows [][]string) string {
+	var commands []string
+	for _, row := range rows {
+		commands = append(commands, a.Expand(header, row))
+	}
+	script := strings.Join(commands, "\n")
+
+	if a.Pager {
+		return "{\n" + script + "\n} 2>&1 | ${PAGER:-less}"
+	}
+	return script + "\nstatus=$?\nprintf '\\n[exit status %s] Press Enter to return to tablefy ' \"$status\"\nread _"
+}
@@ -0,0 +1,82 @@
+package action
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec string
+		want Action
+	}{
+		{"describe=kubectl describe pod {NAME}", Action{Name: "describe", Command: "kubectl describe pod {NAME}"}},
+		{"ctrl+d:describe[pager]=kubectl describe pod {NAME} -o=yaml", Action{Name: "describe", Key: "ctrl+d", Pager: true, Command: "kubectl describe pod {NAME} -o=yaml"}},
+		{"X:delete[confirm, pager]=kubectl delete pod {NAME}", Action{Name: "delete", Key: "X", Confirm: true, Pager: true, Command: "kubectl delete pod {NAME}"}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.spec)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"describe", "=kubectl get pods", "x[force]=rm {NAME}", "logs="} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q): expected an error", spec)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	a := Action{Command: `kubectl logs {NAME} -n {NAMESPACE} | awk '{print $1}' {MISSING}`}
+	got := a.Expand([]string{"NAMESPACE", "NAME"}, []string{"team a", "web"})
+	want := `kubectl logs web -n 'team a' | awk '{print $1}' {MISSING}`
+	if got != want {
+		t.Errorf("Expand = %q, want %q", got, want)
+	}
+}
+
+func TestScript(t *testing.T) {
+	header := []string{"NAME"}
+	rows := [][]string{{"web"}, {"db"}}
+
+	paged := Action{Command: "kubectl describe pod {NAME}", Pager: true}.Script(header, rows)
+	if paged != "{\nkubectl describe pod web\nkubectl describe pod db\n} 2>&1 | ${PAGER:-less}" {
+		t.Errorf("pager script = %q", paged)
+	}
+
+	waiting := Action{Command: "kubectl delete pod {NAME}"}.Script(header, rows)
+	if !strings.HasPrefix(waiting, "kubectl delete pod web\nkubectl delete pod db\n") || !strings.HasSuffix(waiting, "read _") {
+		t.Errorf("script = %q", waiting)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "actions.json")
+	data := `[{"name": "logs", "key": "L", "command": "kubectl logs {NAME}", "pager": true}]`
+	if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	actions, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(actions) != 1 || actions[0] != (Action{Name: "logs", Key: "L", Command: "kubectl logs {NAME}", Pager: true}) {
+		t.Errorf("Load = %+v", actions)
+	}
+
+	if err := os.WriteFile(file, []byte(`[{"name": "logs"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(file); err == nil {
+		t.Error("expected an error for an action without a command")
+	}
+}
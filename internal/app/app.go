@@ -1,44 +1,125 @@
 package app
 
 import (
-	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"tablefy/internal/action"
+	"tablefy/internal/clipboard"
+	"tablefy/internal/export"
+	"tablefy/internal/layout"
 	"tablefy/internal/model"
 	"tablefy/internal/parser"
 	"tablefy/internal/terminal"
+	"tablefy/internal/theme"
 	"tablefy/internal/view"
 )
 
 // Config holds application configuration
 type Config struct {
-	AutoExpand bool
+	AutoExpand    bool
+	Heatmap       bool
+	Theme         string        // Built-in theme name; empty selects the default
+	ThemeFile     string        // Optional JSON theme file overriding theme colors
+	Border        string        // Border style name; empty selects the normal border
+	KeepColors    bool          // Render cells with the input's ANSI colors (always stripped for parsing)
+	ExportColors  string        // ANSI colors in exported data: strip or preserve; empty strips them
+	Output        string        // Export format used when quitting with 'o'; empty selects plain text
+	ExportHeader  bool          // Include the header row in plain exports
+	Template      string        // Go text/template rendering each exported row; replaces the Output format
+	Actions       []string      // Row actions given on the command line, [KEY:]NAME[OPTIONS]=COMMAND
+	ActionsFile   string        // JSON file defining row actions (default $XDG_CONFIG_HOME/tablefy/actions.json if present)
+	ExportColumns []string      // Columns to export by header name instead of the visible ones
+	Preview       string        // Command previewing the row under the cursor, with {HEADER} placeholders
+	PreviewAt     string        // Where the preview pane goes: bottom or right; empty selects bottom
+	OpenWith      string        // Program cells and rows are opened in: pager or editor; empty selects pager
+	RowFormat     string        // Format rows are opened in: yaml or json; empty selects yaml
+	Watch         time.Duration // Re-run Command at this interval instead of reading stdin
+	Command       []string      // Command to run in watch mode
+	Files         []string      // Files to show, one tab each; stdin when empty
+	Format        string        // Input format: auto, text, csv or json; empty reads stdin as text and files by extension
+	JoinOn        string        // Join the two Files on these key columns (LEFT=RIGHT) instead of showing them
+	JoinType      string        // Join type: inner, left or full
+	Diff          bool          // Compare the two Files, matching rows by RowKey, instead of showing them
+	RowKey        []string      // Columns identifying a row across refreshes or diffed files; empty uses the first column
+	Highlight     int           // Refreshes a change stays highlighted in watch mode
+	History       int           // Snapshots kept in watch mode
+	Stream        bool          // Start the UI right away and append rows as stdin is read
+	Follow        bool          // Keep the view scrolled to the last row while streaming
 }
 
 // Run starts the application
 func Run(config Config) error {
-	// Read from stdin
-	scanner := bufio.NewScanner(os.Stdin)
-	var input strings.Builder
+	// Resolve the theme first so a bad theme file fails before reading stdin
+	t, err := theme.Load(config.Theme, config.ThemeFile)
+	if err != nil {
+		return err
+	}
 
-	for scanner.Scan() {
-		input.WriteString(scanner.Text())
-		input.WriteString("\n")
+	border := layout.BorderNormal
+	if config.Border != "" {
+		if border, err = layout.ParseBorderStyle(config.Border); err != nil {
+			return err
+		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading input: %w", err)
+	exportFormat := export.Plain
+	if config.Output != "" {
+		if exportFormat, err = export.ParseFormat(config.Output); err != nil {
+			return err
+		}
 	}
 
-	// Parse the table
-	rows := parser.ParseTable(input.String())
+	if config.Template != "" {
+		if _, err := export.ParseTemplate(config.Template); err != nil {
+			return err
+		}
+		exportFormat = export.Template
+	}
 
-	if len(rows) == 0 {
-		fmt.Println("No data found to format")
-		return nil
+	actions, err := action.Load(config.ActionsFile)
+	if err != nil {
+		return err
+	}
+	for _, spec := range config.Actions {
+		a, err := action.Parse(spec)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, a)
+	}
+
+	if config.Format != "" {
+		if _, err := parser.ParseFormat(config.Format); err != nil {
+			return err
+		}
+	}
+
+	exportColors, err := flagChoice("export-colors", config.ExportColors, "strip", "preserve")
+	if err != nil {
+		return err
+	}
+	previewAt, err := flagChoice("preview-position", config.PreviewAt, "bottom", "right")
+	if err != nil {
+		return err
+	}
+	openWith, err := flagChoice("open-with", config.OpenWith, "pager", "editor")
+	if err != nil {
+		return err
+	}
+	rowFormat, err := flagChoice("row-format", config.RowFormat, "yaml", "json")
+	if err != nil {
+		return err
+	}
+
+	if config.Watch > 0 && config.Stream {
+		return fmt.Errorf("--watch and --stream can't be combined")
 	}
 
 	// Get initial terminal size
@@ -48,22 +129,188 @@ func Run(config Config) error {
 		height = 24
 	}
 
-	// Initialize model
-	m := model.New(rows, width, height)
-	m.AutoExpand = config.AutoExpand
-	m.SetRenderer(view.Render)
+	// newModel applies the settings shared by every table
+	newModel := func(rows [][]string, height int) model.Model {
+		m := model.New(rows, width, height)
+		m.KeepColors = config.KeepColors
+		m.ExportColors = exportColors == "preserve"
+		m.ExportFormat = exportFormat
+		m.ExportTemplate = config.Template
+		m.Actions = actions
+		m.OpenInEditor = openWith == "editor"
+		m.OpenRowJSON = rowFormat == "json"
+		m.ExportHeader = config.ExportHeader
+		m.ExportColumns = config.ExportColumns
+		m.AutoExpand = config.AutoExpand
+		m.Heatmap = config.Heatmap
+		m.Theme = t
+		m.Border = border
+		m.SetRenderer(view.Render)
+		m.SetTableLoader(tableReader(config))
+		m.SetClipboard(clipboard.Copy)
+		if config.Preview != "" {
+			m.SetPreview(config.Preview, previewAt == "right")
+		}
+		return m
+	}
+
+	if config.Watch > 0 {
+		return runWatch(config, newModel(nil, height))
+	}
+	if config.JoinOn != "" {
+		return runJoin(config, newModel(nil, height))
+	}
+	if config.Diff {
+		return runDiff(config, newModel(nil, height))
+	}
+
+	// Open every file up front so a missing one fails before the UI starts
+	inputs := []namedInput{{name: "stdin", reader: os.Stdin, format: inputFormat(config, "-")}}
+	if len(config.Files) > 0 {
+		inputs = nil
+		for _, name := range config.Files {
+			f, err := os.Open(name)
+			if err != nil {
+				return fmt.Errorf("error opening input: %w", err)
+			}
+			defer f.Close()
+			inputs = append(inputs, namedInput{name: filepath.Base(name), reader: f, format: inputFormat(config, name)})
+		}
+	}
+
+	// Read the inputs in the background so the first rows can be browsed while the rest loads
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	newInputModel := func(in namedInput, height int) model.Model {
+		m := newModel(nil, height)
+		inputCtx, inputCancel := context.WithCancel(ctx)
+		m.SetStream(streamInput(inputCtx, in.reader, in.format), inputCancel)
+		m.LiveStream = config.Stream
+		m.Follow = config.Follow
+		return m
+	}
+
+	if len(inputs) == 1 {
+		m := newInputModel(inputs[0], height)
+		m.QuitOnEmptyInput = !config.Stream
+		return runProgram(m, config.Stream)
+	}
+
+	// Several files: one tab each, the tab bar takes a line
+	var tabs []model.Tab
+	for _, in := range inputs {
+		tabs = append(tabs, model.Tab{Name: in.name, Model: newInputModel(in, height-1)})
+	}
+	tabSet := model.NewTabSet(tabs)
+	tabSet.SetRenderer(view.RenderTabs)
+	return runProgram(tabSet, true)
+}
+
+// namedInput is an input to load, with the name shown for it and its format
+type namedInput struct {
+	name   string
+	reader io.Reader
+	format parser.Format
+}
+
+// runWatch runs the command once, then starts the UI refreshing it periodically
+func runWatch(config Config, m model.Model) error {
+	if len(config.Command) == 0 {
+		return fmt.Errorf("--watch needs a command, e.g. tablefy --watch 2s -- kubectl get pods")
+	}
+
+	// Run the command once up front so a failing command is reported before the UI starts
+	refresher := commandRefresher(config.Command, inputFormat(config, "-"))
+	rows, styledRows, err := refresher()
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Println("No data found to format")
+		return nil
+	}
+
+	m.Rows = rows
+	m.StyledRows = styledRows
+	m.HistorySize = config.History
+	m.SetRefresher(refresher, config.Watch)
+	m.RowKey = config.RowKey
+	m.HighlightRefreshes = config.Highlight
+	return runProgram(m, true)
+}
 
-	// Start bubbletea program
-	p := tea.NewProgram(m, tea.WithAltScreen())
+// runProgram runs the UI and prints the exported data once it exits
+// Unless allowEmpty is set, an input that ended without a table is reported instead
+func runProgram(initial tea.Model, allowEmpty bool) error {
+	p := tea.NewProgram(initial, tea.WithAltScreen())
 	final, err := p.Run()
 	if err != nil {
 		return fmt.Errorf("error running program: %w", err)
 	}
 
-	// Print exported data if any (when user pressed 'o')
-	if finalModel, ok := final.(model.Model); ok && finalModel.ExportData != "" {
-		fmt.Println(finalModel.ExportData)
+	var finalModel model.Model
+	var models []model.Model
+	switch final := final.(type) {
+	case model.Model:
+		finalModel = final
+		models = []model.Model{final}
+	case model.TabSet:
+		finalModel = final.Current()
+		for _, tab := range final.Tabs {
+			models = append(models, tab.Model)
+		}
+	default:
+		return nil
+	}
+
+	// Input that ended without a table is reported once the UI is gone
+	if len(finalModel.Rows) == 0 && !allowEmpty {
+		if finalModel.StreamErr != nil {
+			return fmt.Errorf("error reading input: %w", finalModel.StreamErr)
+		}
+		if !finalModel.LoadCancelled {
+			fmt.Println("No data found to format")
+		}
+		return nil
+	}
+
+	// Missing columns are reported even when none were left to export
+	var missing []string
+	seen := make(map[string]bool)
+	for _, m := range models {
+		for _, name := range m.MissingExportColumns() {
+			if !seen[name] {
+				seen[name] = true
+				missing = append(missing, name)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: --export-columns not found in the table: %s\n", strings.Join(missing, ", "))
+	}
+
+	// Print the exported data of every tab, in tab order (when user pressed 'o', or exported to -)
+	for _, m := range models {
+		data, err := m.PendingExport()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: export failed: %v\n", err)
+			continue
+		}
+		if data != "" {
+			fmt.Println(data)
+		}
 	}
 
 	return nil
 }
+
+// flagChoice checks the value of a flag taking one of two choices; empty selects the first
+func flagChoice(flag, value, first, second string) (string, error) {
+	switch value {
+	case "":
+		return first, nil
+	case first, second:
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid --%s value %q (use %s or %s)", flag, value, first, second)
+}
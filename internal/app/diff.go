@@ -0,0 +1,46 @@
+package app
+
+import (
+	"fmt"
+
+	"tablefy/internal/diff"
+	"tablefy/internal/model"
+)
+
+// runDiff compares the two input files row by row, matching rows by the key columns
+func runDiff(config Config, m model.Model) error {
+	if len(config.Files) != 2 {
+		return fmt.Errorf("diff needs two inputs (use - for stdin), e.g. tablefy diff yesterday.txt today.txt --key NAMESPACE,NAME")
+	}
+
+	oldRows, err := readTable(config.Files[0], inputFormat(config, config.Files[0]))
+	if err != nil {
+		return err
+	}
+	newRows, err := readTable(config.Files[1], inputFormat(config, config.Files[1]))
+	if err != nil {
+		return err
+	}
+
+	// A misspelled key would silently fall back to the first column and report every row as changed
+	for _, name := range config.RowKey {
+		for i, rows := range [][][]string{oldRows, newRows} {
+			if !hasColumn(rows[0], name) {
+				return fmt.Errorf("key column %q not found in %s", name, config.Files[i])
+			}
+		}
+	}
+
+	m.SetDiff(diff.CompareAll(oldRows, newRows, config.RowKey))
+	return runProgram(m, true)
+}
+
+// hasColumn reports whether the header has a column with the given name
+func hasColumn(header []string, name string) bool {
+	for _, h := range header {
+		if h == name {
+			return true
+		}
+	}
+	return false
+}
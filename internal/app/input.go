@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"tablefy/internal/input"
+	"tablefy/internal/model"
+	"tablefy/internal/parser"
+)
+
+// inputFormat returns the format to read an input in: the --format value when given,
+// otherwise aligned text for stdin ("-") and the format of a known file extension
+// The --format value was checked by Run
+func inputFormat(config Config, path string) parser.Format {
+	if config.Format != "" {
+		format, _ := parser.ParseFormat(config.Format)
+		return format
+	}
+	if path == "-" {
+		return parser.FormatText
+	}
+	return parser.FormatForFile(path)
+}
+
+// tableReader returns a loader reading whole inputs in the formats config picks
+func tableReader(config Config) model.TableLoader {
+	return func(path string) ([][]string, error) {
+		return readTable(path, inputFormat(config, path))
+	}
+}
+
+// readTable reads and parses a whole input in the format: a file, or stdin for "-"
+// Compression is detected the same way as for the main input
+func readTable(path string, format parser.Format) ([][]string, error) {
+	r := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("error opening input: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	decompressed, _, err := input.Decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	defer decompressed.Close()
+
+	lines := parser.NewLineParser(format)
+	reader := input.NewReader(decompressed)
+	var rows [][]string
+	for {
+		line, _, err := reader.ReadLine()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", path, err)
+		}
+		if row, _, ok := lines.Line(line); ok {
+			rows = append(rows, row)
+		}
+	}
+	rows = append(rows, lines.Flush()...)
+
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, fmt.Errorf("no table found in %s", path)
+	}
+	return rows, nil
+}
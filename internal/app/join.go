@@ -0,0 +1,54 @@
+package app
+
+import (
+	"fmt"
+
+	"tablefy/internal/join"
+	"tablefy/internal/model"
+)
+
+// runJoin joins the two input files on the key columns and shows the result as an ordinary table
+func runJoin(config Config, m model.Model) error {
+	if len(config.Files) != 2 {
+		return fmt.Errorf("join needs two inputs (use - for stdin), e.g. tablefy join nodes.txt pods.txt --on NAME=NODE")
+	}
+	leftKey, rightKey, err := join.ParseOn(config.JoinOn)
+	if err != nil {
+		return err
+	}
+	kind, err := join.ParseKind(config.JoinType)
+	if err != nil {
+		return err
+	}
+
+	left, err := readTable(config.Files[0], inputFormat(config, config.Files[0]))
+	if err != nil {
+		return err
+	}
+	right, err := readTable(config.Files[1], inputFormat(config, config.Files[1]))
+	if err != nil {
+		return err
+	}
+
+	leftName, rightName := join.PrefixFor(config.Files[0]), join.PrefixFor(config.Files[1])
+	if leftName == rightName {
+		leftName, rightName = "left", "right"
+	}
+	rows, err := join.Join(left, right, join.Options{
+		LeftKey:     leftKey,
+		RightKey:    rightKey,
+		Kind:        kind,
+		LeftPrefix:  leftName,
+		RightPrefix: rightName,
+	})
+	if err != nil {
+		return err
+	}
+	if len(rows) < 2 {
+		fmt.Println("No rows matched the join")
+		return nil
+	}
+
+	m.Rows = rows
+	return runProgram(m, true)
+}
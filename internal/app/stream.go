@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"tablefy/internal/input"
+	"tablefy/internal/model"
+	"tablefy/internal/parser"
+)
+
+const (
+	streamBatchInterval = 50 * time.Millisecond // How often parsed rows are handed to the UI
+	streamBatchSize     = 1000                  // Rows after which a batch is handed over right away
+)
+
+// parsedLine is one input line: the table row parsed from it (nil for blank lines), or the error that ended the input
+type parsedLine struct {
+	row, styled           []string
+	bytes                 int64
+	malformed, firstBadAt int // Lines sanitized so far and the first of them
+	err                   error
+}
+
+// streamInput parses r line by line in the given format in the background and delivers the rows in batches
+// The returned channel is closed once r reaches EOF or fails, or ctx is cancelled
+func streamInput(ctx context.Context, r io.Reader, format parser.Format) <-chan model.StreamBatch {
+	lines := make(chan parsedLine, streamBatchSize)
+	go func() {
+		defer close(lines)
+		// Compressed input is detected by its magic bytes and decompressed on the fly
+		decompressed, _, err := input.Decompress(r)
+		if err != nil {
+			select {
+			case lines <- parsedLine{err: err}:
+			case <-ctx.Done():
+			}
+			return
+		}
+		defer decompressed.Close()
+
+		stream := parser.NewLineParser(format)
+		reader := input.NewReader(decompressed)
+		for {
+			text, size, err := reader.ReadLine()
+			if err == io.EOF {
+				// Some formats (JSON) only produce their rows once the input is complete
+				for _, row := range stream.Flush() {
+					select {
+					case lines <- parsedLine{row: row, styled: row}:
+					case <-ctx.Done():
+						return
+					}
+				}
+				return
+			}
+			if err != nil {
+				select {
+				case lines <- parsedLine{err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			line := parsedLine{bytes: int64(size)}
+			line.row, line.styled, _ = stream.Line(text)
+			line.malformed, line.firstBadAt = reader.Malformed()
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	batches := make(chan model.StreamBatch)
+	go func() {
+		defer close(batches)
+		ticker := time.NewTicker(streamBatchInterval)
+		defer ticker.Stop()
+
+		var batch model.StreamBatch
+		flush := func() bool {
+			if len(batch.Rows) == 0 && batch.Bytes == 0 && batch.Err == nil {
+				return true
+			}
+			select {
+			case batches <- batch:
+				batch = model.StreamBatch{}
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					flush()
+					return
+				}
+				if line.err != nil {
+					batch.Err = line.err
+					continue
+				}
+				batch.Bytes += line.bytes
+				batch.MalformedLines, batch.FirstMalformedLine = line.malformed, line.firstBadAt
+				if line.row != nil {
+					batch.Rows = append(batch.Rows, line.row)
+					batch.StyledRows = append(batch.StyledRows, line.styled)
+				}
+				if len(batch.Rows) >= streamBatchSize && !flush() {
+					return
+				}
+			case <-ticker.C:
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return batches
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"tablefy/internal/input"
+	"tablefy/internal/model"
+	"tablefy/internal/parser"
+)
+
+// commandRefresher returns a Refresher that runs the command and parses its output in the format
+func commandRefresher(command []string, format parser.Format) model.Refresher {
+	return func() ([][]string, [][]string, error) {
+		output, err := runCommand(command)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows, styled := parser.Parse(input.SanitizeText(output), format)
+		return rows, styled, nil
+	}
+}
+
+// runCommand runs the command and returns its standard output
+// A single argument is run through the shell, like watch(1), so pipes and quoting work
+func runCommand(command []string) (string, error) {
+	var cmd *exec.Cmd
+	if len(command) == 1 {
+		cmd = exec.Command("sh", "-c", command[0])
+	} else {
+		cmd = exec.Command(command[0], command[1:]...)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		// Prefer the command's own message, it fits better in the status line than "exit status 1"
+		if msg := firstLine(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", strings.Join(command, " "), msg)
+		}
+		return "", fmt.Errorf("%s: %w", strings.Join(command, " "), err)
+	}
+	return string(output), nil
+}
+
+// firstLine returns the first non-empty line of s
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
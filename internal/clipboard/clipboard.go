@@ -0,0 +1,41 @@
+package clipboard
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Copy returns the command putting text on the system clipboard with an OSC 52 escape sequence
+// The terminal does the copying, so it also works over SSH; tmux and screen get the sequence wrapped for them.
+// The program releases the terminal while the sequence is written, so it never lands in the middle of a frame.
+// It goes to stderr, which stays the terminal when the exported data on stdout is redirected.
+func Copy(text string, done tea.ExecCallback) tea.Cmd {
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case os.Getenv("STY") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+	return tea.Exec(&write{seq: seq, stderr: os.Stderr}, done)
+}
+
+// write writes the escape sequence as a command run by tea.Exec, which hands it the terminal's stderr
+type write struct {
+	seq    osc52.Sequence
+	stderr io.Writer
+}
+
+// Run writes the sequence
+func (w *write) Run() error {
+	_, err := w.seq.WriteTo(w.stderr)
+	return err
+}
+
+func (w *write) SetStdin(io.Reader)    {}
+func (w *write) SetStdout(io.Writer)   {}
+func (w *write) SetStderr(s io.Writer) { w.stderr = s }
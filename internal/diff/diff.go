@@ -0,0 +1,253 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind describes how a row differs between two tables
+type Kind int
+
+const (
+	Added     Kind = iota // Row only in the new table
+	Removed               // Row only in the old table
+	Changed               // Row in both tables with different values
+	Unchanged             // Row in both tables with the same values (only reported by CompareAll)
+)
+
+// Marker returns the symbol shown for the kind in front of compared rows
+func (k Kind) Marker() string {
+	switch k {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	case Unchanged:
+		return " "
+	default:
+		return "~"
+	}
+}
+
+// String returns the name used for the kind in diff tables
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Unchanged:
+		return "unchanged"
+	default:
+		return "changed"
+	}
+}
+
+// Row is a row that differs between two tables
+type Row struct {
+	Kind    Kind
+	Key     string
+	Cells   map[string]string // Values by column name: the new values, or the old ones for removed rows
+	Old     map[string]string // Previous values of the changed columns
+	Columns []string          // Names of the changed columns, in header order
+}
+
+// Result is the difference between two tables
+type Result struct {
+	Header []string // Columns of the new table, followed by columns only found in the old table
+	Rows   []Row    // Added and changed rows in new-table order, then removed rows in old-table order
+}
+
+// Keys computes the identity of every row from the key columns (by header name)
+// Without key columns, or when none of them exist, the first column identifies the row.
+// Duplicate keys get a numeric suffix so every row has a distinct identity.
+// The result is aligned with rows; the header's key is empty.
+func Keys(rows [][]string, keyColumns []string) []string {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var keyIdx []int
+	for _, name := range keyColumns {
+		for i, h := range rows[0] {
+			if h == name {
+				keyIdx = append(keyIdx, i)
+				break
+			}
+		}
+	}
+	if len(keyIdx) == 0 {
+		keyIdx = []int{0}
+	}
+
+	keys := make([]string, len(rows))
+	seen := make(map[string]int)
+	for i := 1; i < len(rows); i++ {
+		var parts []string
+		for _, idx := range keyIdx {
+			if idx < len(rows[i]) {
+				parts = append(parts, rows[i][idx])
+			} else {
+				parts = append(parts, "")
+			}
+		}
+		key := strings.Join(parts, "\x1f")
+		if n := seen[key]; n > 0 {
+			seen[key]++
+			key = fmt.Sprintf("%s#%d", key, n)
+		} else {
+			seen[key] = 1
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+// CellsByName maps a row's values to its header names
+func CellsByName(header, row []string) map[string]string {
+	cells := make(map[string]string, len(header))
+	for i, name := range header {
+		if i < len(row) {
+			cells[name] = row[i]
+		}
+	}
+	return cells
+}
+
+// Compare matches the rows of two tables (header at index 0) by key and reports what changed
+// Cells are compared by column name, so added, removed or reordered columns are not reported as changes
+func Compare(oldRows, newRows [][]string, keyColumns []string) Result {
+	return compare(oldRows, newRows, keyColumns, false)
+}
+
+// CompareAll is Compare with the rows that did not change included, as Unchanged, in new-table order
+func CompareAll(oldRows, newRows [][]string, keyColumns []string) Result {
+	return compare(oldRows, newRows, keyColumns, true)
+}
+
+// compare implements Compare and CompareAll
+func compare(oldRows, newRows [][]string, keyColumns []string, unchanged bool) Result {
+	var result Result
+	if len(newRows) > 0 {
+		result.Header = append(result.Header, newRows[0]...)
+	}
+	if len(oldRows) > 0 {
+		inNew := make(map[string]bool, len(result.Header))
+		for _, name := range result.Header {
+			inNew[name] = true
+		}
+		for _, name := range oldRows[0] {
+			if !inNew[name] {
+				result.Header = append(result.Header, name)
+			}
+		}
+	}
+	if len(oldRows) == 0 || len(newRows) == 0 {
+		return result
+	}
+
+	oldKeys := Keys(oldRows, keyColumns)
+	oldByKey := make(map[string][]string, len(oldRows))
+	for i := 1; i < len(oldRows); i++ {
+		oldByKey[oldKeys[i]] = oldRows[i]
+	}
+
+	newKeys := Keys(newRows, keyColumns)
+	present := make(map[string]bool, len(newRows))
+	for i := 1; i < len(newRows); i++ {
+		key := newKeys[i]
+		present[key] = true
+		cells := CellsByName(newRows[0], newRows[i])
+
+		oldRow, existed := oldByKey[key]
+		if !existed {
+			result.Rows = append(result.Rows, Row{Kind: Added, Key: key, Cells: cells})
+			continue
+		}
+
+		oldCells := CellsByName(oldRows[0], oldRow)
+		row := Row{Kind: Changed, Key: key, Cells: cells, Old: make(map[string]string)}
+		for _, name := range newRows[0] {
+			oldValue, ok := oldCells[name]
+			newValue, inRow := cells[name]
+			if !ok || !inRow || oldValue == newValue {
+				continue
+			}
+			row.Columns = append(row.Columns, name)
+			row.Old[name] = oldValue
+		}
+		if len(row.Columns) > 0 {
+			result.Rows = append(result.Rows, row)
+		} else if unchanged {
+			result.Rows = append(result.Rows, Row{Kind: Unchanged, Key: key, Cells: cells})
+		}
+	}
+
+	for i := 1; i < len(oldRows); i++ {
+		if !present[oldKeys[i]] {
+			result.Rows = append(result.Rows, Row{Kind: Removed, Key: oldKeys[i], Cells: CellsByName(oldRows[0], oldRows[i])})
+		}
+	}
+	return result
+}
+
+// Filter returns the result with only the rows of the given kinds
+func (r Result) Filter(kinds ...Kind) Result {
+	filtered := Result{Header: r.Header}
+	for _, row := range r.Rows {
+		for _, kind := range kinds {
+			if row.Kind == kind {
+				filtered.Rows = append(filtered.Rows, row)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// Count returns the number of rows of the given kind
+func (r Result) Count(kind Kind) int {
+	n := 0
+	for _, row := range r.Rows {
+		if row.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// KindColumn is the first column of Table, naming the kind of every row
+const KindColumn = "CHANGE"
+
+// Table lays the result out as rows for the exporters: a header, then one row per difference
+// The CHANGE column names the kind; every column that changed in some row is followed by
+// an "old." column holding its previous value, empty where it didn't change
+func (r Result) Table() [][]string {
+	changed := make(map[string]bool)
+	for _, row := range r.Rows {
+		for name := range row.Old {
+			changed[name] = true
+		}
+	}
+
+	header := []string{KindColumn}
+	for _, name := range r.Header {
+		header = append(header, name)
+		if changed[name] {
+			header = append(header, "old."+name)
+		}
+	}
+
+	table := [][]string{header}
+	for _, row := range r.Rows {
+		line := []string{row.Kind.String()}
+		for _, name := range r.Header {
+			line = append(line, row.Cells[name])
+			if changed[name] {
+				line = append(line, row.Old[name])
+			}
+		}
+		table = append(table, line)
+	}
+	return table
+}
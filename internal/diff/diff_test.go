@@ -0,0 +1,117 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeys(t *testing.T) {
+	rows := [][]string{
+		{"NAMESPACE", "NAME", "STATUS"},
+		{"default", "web", "Running"},
+		{"kube-system", "web", "Running"},
+		{"default", "web", "Pending"},
+	}
+
+	keys := Keys(rows, []string{"NAMESPACE", "NAME"})
+	if keys[0] != "" {
+		t.Errorf("header key = %q, want empty", keys[0])
+	}
+	if keys[1] == keys[2] {
+		t.Errorf("rows in different namespaces share key %q", keys[1])
+	}
+	if keys[1] == keys[3] {
+		t.Errorf("duplicate rows share key %q, want a suffix", keys[1])
+	}
+
+	// Unknown key columns fall back to the first column
+	keys = Keys(rows, []string{"MISSING"})
+	if keys[1] != "default" || keys[2] != "kube-system" || keys[3] != "default#1" {
+		t.Errorf("fallback keys = %q", keys[1:])
+	}
+}
+
+func TestCompare(t *testing.T) {
+	oldRows := [][]string{
+		{"NAME", "STATUS", "AGE"},
+		{"web", "Running", "1d"},
+		{"db", "Running", "3d"},
+		{"cache", "Running", "2d"},
+	}
+	// STATUS and NAME are swapped and AGE is gone: only real value changes count
+	newRows := [][]string{
+		{"STATUS", "NAME", "RESTARTS"},
+		{"Running", "web", "0"},
+		{"CrashLoopBackOff", "db", "4"},
+		{"Pending", "queue", "0"},
+	}
+
+	result := Compare(oldRows, newRows, []string{"NAME"})
+
+	wantHeader := []string{"STATUS", "NAME", "RESTARTS", "AGE"}
+	if strings.Join(result.Header, ",") != strings.Join(wantHeader, ",") {
+		t.Errorf("Header = %v, want %v", result.Header, wantHeader)
+	}
+	if len(result.Rows) != 3 {
+		t.Fatalf("expected 3 differing rows, got %+v", result.Rows)
+	}
+
+	changed := result.Rows[0]
+	if changed.Kind != Changed || changed.Key != "db" || len(changed.Columns) != 1 || changed.Old["STATUS"] != "Running" {
+		t.Errorf("unexpected changed row %+v", changed)
+	}
+	if added := result.Rows[1]; added.Kind != Added || added.Cells["NAME"] != "queue" {
+		t.Errorf("unexpected added row %+v", added)
+	}
+	if removed := result.Rows[2]; removed.Kind != Removed || removed.Cells["AGE"] != "2d" {
+		t.Errorf("unexpected removed row %+v", removed)
+	}
+	if result.Count(Changed) != 1 || result.Count(Added) != 1 || result.Count(Removed) != 1 {
+		t.Errorf("unexpected counts in %+v", result.Rows)
+	}
+}
+
+func TestCompareAll(t *testing.T) {
+	oldRows := [][]string{{"NAME", "STATUS"}, {"web", "Running"}, {"db", "Running"}, {"cache", "Running"}}
+	newRows := [][]string{{"NAME", "STATUS"}, {"web", "Running"}, {"db", "Failed"}, {"queue", "Pending"}}
+
+	result := CompareAll(oldRows, newRows, nil)
+
+	var got []string
+	for _, row := range result.Rows {
+		got = append(got, row.Kind.Marker()+row.Key)
+	}
+	if want := " web,~db,+queue,-cache"; strings.Join(got, ",") != want {
+		t.Errorf("rows = %q, want %q", strings.Join(got, ","), want)
+	}
+
+	changes := result.Filter(Added, Removed)
+	if len(changes.Rows) != 2 || changes.Rows[0].Key != "queue" || changes.Rows[1].Key != "cache" {
+		t.Errorf("Filter(Added, Removed) = %+v", changes.Rows)
+	}
+}
+
+func TestTable(t *testing.T) {
+	oldRows := [][]string{
+		{"NAME", "STATUS", "AGE"},
+		{"web", "Running", "1d"},
+		{"db", "Running", "2d"},
+	}
+	newRows := [][]string{
+		{"NAME", "STATUS", "AGE"},
+		{"web", "Pending", "1d"},
+		{"queue", "Running", "5m"},
+	}
+
+	got := Compare(oldRows, newRows, nil).Table()
+	want := [][]string{
+		{"CHANGE", "NAME", "STATUS", "old.STATUS", "AGE"},
+		{"changed", "web", "Pending", "Running", "1d"},
+		{"added", "queue", "Running", "", "5m"},
+		{"removed", "db", "Running", "", "2d"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Table() = %v, want %v", got, want)
+	}
+}
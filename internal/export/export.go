@@ -0,0 +1,252 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"text/template"
+
+	"tablefy/internal/layout"
+)
+
+// Format names an export format
+type Format string
+
+const (
+	Plain    Format = "plain"    // Space-aligned columns without borders or header
+	CSV      Format = "csv"      // Comma-separated values, quoted where needed
+	TSV      Format = "tsv"      // Tab-separated values
+	JSON     Format = "json"     // Array of objects keyed by header
+	NDJSON   Format = "ndjson"   // One JSON object per line
+	Markdown Format = "markdown" // GitHub-flavored Markdown table
+	HTML     Format = "html"     // Standalone HTML document with a table
+	Template Format = "template" // Rows rendered through Options.Template, see ExecuteTemplate
+)
+
+// Table is the data to export: a header and the rows under it, already filtered and reduced to the exported columns
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// Options adjusts an export
+type Options struct {
+	Header   bool               // Include the header row in plain exports (the other formats always have it)
+	Template *template.Template // Row template of the template format, from ParseTemplate
+}
+
+// Exporter renders a table in one format
+type Exporter func(Table, Options) string
+
+// exporters holds the exporter of every format by name
+var exporters = map[Format]Exporter{
+	Plain:    exportPlain,
+	CSV:      exportCSV,
+	TSV:      exportTSV,
+	JSON:     exportJSON,
+	NDJSON:   exportNDJSON,
+	Markdown: exportMarkdown,
+	HTML:     exportHTML,
+}
+
+// Names returns the names of the export formats in alphabetical order
+func Names() []string {
+	names := []string{string(Template)}
+	for format := range exporters {
+		names = append(names, string(format))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseFormat returns the format with the given name; "md" and "jsonl" are accepted as aliases
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "md":
+		return Markdown, nil
+	case "jsonl":
+		return NDJSON, nil
+	}
+	format := Format(strings.ToLower(name))
+	if _, ok := exporters[format]; !ok && format != Template {
+		return "", fmt.Errorf("unknown export format %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return format, nil
+}
+
+// Export renders the table in the given format
+// The template format renders every row through opts.Template, and fails without one or when a row doesn't fit it
+func Export(format Format, t Table, opts Options) (string, error) {
+	if format == Template {
+		if opts.Template == nil {
+			return "", fmt.Errorf("no template set")
+		}
+		return ExecuteTemplate(opts.Template, t)
+	}
+	exporter, ok := exporters[format]
+	if !ok {
+		return "", fmt.Errorf("unknown export format %q (available: %s)", format, strings.Join(Names(), ", "))
+	}
+	return exporter(t, opts), nil
+}
+
+// exportPlain aligns the rows in columns two spaces apart
+// The header is left out unless asked for, so the output pipes into xargs & co
+func exportPlain(t Table, opts Options) string {
+	if len(t.Rows) == 0 {
+		return ""
+	}
+
+	rows := t.Rows
+	if opts.Header {
+		rows = append([][]string{t.Header}, t.Rows...)
+	}
+
+	// Calculate optimal column widths based on the data
+	widths := layout.CalculateFullColumnWidths(rows)
+
+	var lines []string
+	for _, row := range rows {
+		var padded []string
+		for col, value := range row {
+			width := 0
+			if col < len(widths) {
+				width = widths[col]
+			}
+			// Pad the value to the column width (in terminal cells, not bytes)
+			padded = append(padded, layout.PadRight(value, width))
+		}
+		lines = append(lines, strings.Join(padded, "  "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// exportCSV writes comma-separated values
+func exportCSV(t Table, _ Options) string {
+	return exportDelimited(t, ',')
+}
+
+// exportDelimited writes the header and rows with encoding/csv, which quotes values containing the separator, quotes or newlines
+func exportDelimited(t Table, separator rune) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = separator
+	// Write only fails on the underlying writer, which is a buffer
+	_ = w.Write(t.Header)
+	_ = w.WriteAll(t.Rows)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// tsvClean replaces the characters TSV can't hold inside a value
+var tsvClean = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// TSVLine joins values with tabs; tabs and newlines inside values become spaces, as TSV has no quoting
+func TSVLine(row []string) string {
+	cells := make([]string, len(row))
+	for i, value := range row {
+		cells[i] = tsvClean.Replace(value)
+	}
+	return strings.Join(cells, "\t")
+}
+
+// exportTSV writes the header and every row as TSV lines
+func exportTSV(t Table, _ Options) string {
+	lines := []string{TSVLine(t.Header)}
+	for _, row := range t.Rows {
+		lines = append(lines, TSVLine(row))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// jsonObject encodes a row as a JSON object keyed by header, keeping the column order
+func jsonObject(header, row []string) string {
+	var fields []string
+	for i, name := range header {
+		value := ""
+		if i < len(row) {
+			value = row[i]
+		}
+		key, _ := json.Marshal(name)
+		val, _ := json.Marshal(value)
+		fields = append(fields, string(key)+":"+string(val))
+	}
+	return "{" + strings.Join(fields, ",") + "}"
+}
+
+// exportJSON writes an array with one object per row, one row per line
+func exportJSON(t Table, _ Options) string {
+	if len(t.Rows) == 0 {
+		return "[]"
+	}
+	var objects []string
+	for _, row := range t.Rows {
+		objects = append(objects, "  "+jsonObject(t.Header, row))
+	}
+	return "[\n" + strings.Join(objects, ",\n") + "\n]"
+}
+
+// exportNDJSON writes one object per line
+func exportNDJSON(t Table, _ Options) string {
+	var lines []string
+	for _, row := range t.Rows {
+		lines = append(lines, jsonObject(t.Header, row))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// exportMarkdown writes a GitHub-flavored Markdown table
+func exportMarkdown(t Table, _ Options) string {
+	escape := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	line := func(row []string) string {
+		cells := make([]string, len(t.Header))
+		for i := range t.Header {
+			if i < len(row) {
+				cells[i] = escape.Replace(row[i])
+			}
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	separator := make([]string, len(t.Header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	lines := []string{line(t.Header), "| " + strings.Join(separator, " | ") + " |"}
+	for _, row := range t.Rows {
+		lines = append(lines, line(row))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// exportHTML writes a standalone HTML document that opens in any browser
+func exportHTML(t Table, _ Options) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>tablefy export</title>\n")
+	b.WriteString("<style>\ntable { border-collapse: collapse; font-family: sans-serif; font-size: 14px; }\n")
+	b.WriteString("th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }\n")
+	b.WriteString("th { background: #f0f0f0; }\n</style>\n</head>\n<body>\n<table>\n")
+
+	b.WriteString("<thead>\n<tr>")
+	for _, name := range t.Header {
+		b.WriteString("<th>" + html.EscapeString(name) + "</th>")
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range t.Rows {
+		b.WriteString("<tr>")
+		for i := range t.Header {
+			value := ""
+			if i < len(row) {
+				value = row[i]
+			}
+			b.WriteString("<td>" + html.EscapeString(value) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n</body>\n</html>")
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+var sample = Table{
+	Header: []string{"NAME", "NOTE"},
+	Rows: [][]string{
+		{"web", `says "hi", twice`},
+		{"db|1", "a\tb"},
+		{"<x>", ""},
+	},
+}
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{Plain, "web   says \"hi\", twice\ndb|1  a\tb              \n<x>                   "},
+		{CSV, "NAME,NOTE\nweb,\"says \"\"hi\"\", twice\"\ndb|1,a\tb\n<x>,"},
+		{TSV, "NAME\tNOTE\nweb\tsays \"hi\", twice\ndb|1\ta b\n<x>\t"},
+		{NDJSON, `{"NAME":"web","NOTE":"says \"hi\", twice"}` + "\n" + `{"NAME":"db|1","NOTE":"a\tb"}` + "\n" + `{"NAME":"\u003cx\u003e","NOTE":""}`},
+		{Markdown, "| NAME | NOTE |\n| --- | --- |\n| web | says \"hi\", twice |\n| db\\|1 | a\tb |\n| <x> |  |"},
+	}
+	for _, tt := range tests {
+		if got, err := Export(tt.format, sample, Options{}); err != nil || got != tt.want {
+			t.Errorf("Export(%s) =\n%q\nwant\n%q", tt.format, got, tt.want)
+		}
+	}
+}
+
+// mustExport exports the table, failing the test on an error
+func mustExport(t *testing.T, format Format, table Table, opts Options) string {
+	t.Helper()
+	got, err := Export(format, table, opts)
+	if err != nil {
+		t.Fatalf("Export(%s): %v", format, err)
+	}
+	return got
+}
+
+func TestExportTemplate(t *testing.T) {
+	if _, err := Export(Template, sample, Options{}); err == nil {
+		t.Error("the template format should fail without a template")
+	}
+
+	tmpl, err := ParseTemplate("{{.NAME}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mustExport(t, Template, sample, Options{Template: tmpl}); got != "web\ndb|1\n<x>" {
+		t.Errorf("Export(template) = %q", got)
+	}
+}
+
+func TestExportPlainHeader(t *testing.T) {
+	table := Table{Header: []string{"NAME", "STATUS"}, Rows: [][]string{{"web", "Running"}}}
+	if got, want := mustExport(t, Plain, table, Options{Header: true}), "NAME  STATUS \nweb   Running"; got != want {
+		t.Errorf("plain export with header = %q, want %q", got, want)
+	}
+	// Only plain exports make the header optional
+	if got := mustExport(t, CSV, table, Options{}); !strings.HasPrefix(got, "NAME,STATUS\n") {
+		t.Errorf("CSV export lost its header: %q", got)
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	got := mustExport(t, JSON, sample, Options{})
+	if !strings.HasPrefix(got, "[\n  {\"NAME\":\"web\",") || !strings.HasSuffix(got, "}\n]") {
+		t.Errorf("unexpected JSON:\n%s", got)
+	}
+	if got := mustExport(t, JSON, Table{Header: []string{"NAME"}}, Options{}); got != "[]" {
+		t.Errorf("empty JSON = %q, want []", got)
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	got := mustExport(t, HTML, sample, Options{})
+	for _, want := range []string{"<!DOCTYPE html>", "<th>NAME</th>", "<td>&lt;x&gt;</td>", "<td>says &#34;hi&#34;, twice</td>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML export is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"csv": CSV, "JSON": JSON, "md": Markdown, "jsonl": NDJSON} {
+		if got, err := ParseFormat(name); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
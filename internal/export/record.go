@@ -0,0 +1,99 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// PrettyJSON indents a value holding a JSON object or array; ok is false for anything else
+func PrettyJSON(value string) (string, bool) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return value, false
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(trimmed), "", "  "); err != nil {
+		return value, false
+	}
+	return out.String(), true
+}
+
+// RecordJSON writes a row as an indented JSON object keyed by header, keeping the column order
+// Values holding JSON objects or arrays are embedded as JSON rather than as strings
+func RecordJSON(header, row []string) string {
+	var fields []string
+	for i, name := range header {
+		value := cell(row, i)
+		key, _ := json.Marshal(name)
+		val, _ := json.Marshal(value)
+		if trimmed := strings.TrimSpace(value); json.Valid([]byte(trimmed)) && (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) {
+			val = []byte(trimmed)
+		}
+		fields = append(fields, string(key)+":"+string(val))
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte("{"+strings.Join(fields, ",")+"}"), "", "  "); err != nil {
+		return jsonObject(header, row)
+	}
+	return out.String()
+}
+
+// RecordYAML writes a row as a YAML mapping keyed by header, keeping the column order
+// Multi-line values and pretty-printed JSON values become literal blocks
+func RecordYAML(header, row []string) string {
+	var lines []string
+	for i, name := range header {
+		value := cell(row, i)
+		if pretty, ok := PrettyJSON(value); ok {
+			value = pretty
+		}
+
+		if strings.Contains(value, "\n") && !strings.HasPrefix(value, " ") {
+			lines = append(lines, yamlScalar(name)+": |-")
+			for _, line := range strings.Split(value, "\n") {
+				lines = append(lines, strings.TrimRight("  "+line, " "))
+			}
+			continue
+		}
+		lines = append(lines, yamlScalar(name)+": "+yamlScalar(value))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// yamlReserved holds the plain words YAML would read as something other than a string
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true,
+	"null": true, "~": true, "y": true, "n": true,
+}
+
+// yamlTyped matches the plain values YAML reads as a number or a date: 1, +1.5, 0x1F, 0o17, 0b101, 1_000, 1e3,
+// .5, .inf, .nan, the base 60 1:20 of YAML 1.1, and dates such as 2024-01-31
+var yamlTyped = regexp.MustCompile(`^(?:[-+]?(?:0b[01_]+|0o?[0-7_]+|0x[0-9a-fA-F_]+|[0-9][0-9_]*(?::[0-5]?[0-9])*(?:\.[0-9_]*)?(?:[eE][-+]?[0-9]+)?|\.[0-9][0-9_]*(?:[eE][-+]?[0-9]+)?|\.(?:inf|Inf|INF|nan|NaN|NAN))|[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}(?:[Tt ].*)?)$`)
+
+// yamlScalar writes a value plain when YAML reads it back as the same string, double-quoted otherwise
+func yamlScalar(value string) string {
+	plain := value != "" &&
+		value == strings.TrimSpace(value) &&
+		!strings.ContainsAny(value[:1], "-?:,[]{}#&*!|>'\"%@`") &&
+		!strings.ContainsAny(value, "\n\t") &&
+		!strings.Contains(value, ": ") && !strings.Contains(value, " #") &&
+		!strings.HasSuffix(value, ":") &&
+		!yamlReserved[strings.ToLower(value)] &&
+		!yamlTyped.MatchString(value)
+	if plain {
+		return value
+	}
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
+
+// cell returns the value in column i of the row, empty when the row is short
+func cell(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package export
+
+import "testing"
+
+func TestPrettyJSON(t *testing.T) {
+	tests := []struct {
+		value, want string
+		ok          bool
+	}{
+		{`{"a":1,"b":[true]}`, "{\n  \"a\": 1,\n  \"b\": [\n    true\n  ]\n}", true},
+		{` [1,2] `, "[\n  1,\n  2\n]", true},
+		{`{not json`, `{not json`, false},
+		{`42`, `42`, false},
+		{`plain text`, `plain text`, false},
+	}
+	for _, tt := range tests {
+		got, ok := PrettyJSON(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("PrettyJSON(%q) = %q, %v; want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRecordYAML(t *testing.T) {
+	header := []string{"NAME", "READY", "ARGS", "LABELS", "NOTE", "EMPTY"}
+	row := []string{"web-7d9f", "true", "--port: 80", `{"app":"web"}`, "line one\nline two"}
+
+	want := `NAME: web-7d9f
+READY: "true"
+ARGS: "--port: 80"
+LABELS: |-
+  {
+    "app": "web"
+  }
+NOTE: |-
+  line one
+  line two
+EMPTY: ""`
+	if got := RecordYAML(header, row); got != want {
+		t.Errorf("RecordYAML =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestYAMLScalar(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"web", "web"},
+		{"1", `"1"`},
+		{"0x1F", `"0x1F"`},
+		{"1e3", `"1e3"`},
+		{"+1.5", `"+1.5"`},
+		{".5", `".5"`},
+		{".inf", `".inf"`},
+		{"1_000", `"1_000"`},
+		{"1:20", `"1:20"`},
+		{"2024-01-31", `"2024-01-31"`},
+		{"1.2.3", "1.2.3"},
+		{"10Gi", "10Gi"},
+		{"3d", "3d"},
+		{"1/1", "1/1"},
+	}
+	for _, tt := range tests {
+		if got := yamlScalar(tt.value); got != tt.want {
+			t.Errorf("yamlScalar(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRecordJSON(t *testing.T) {
+	got := RecordJSON([]string{"NAME", "LABELS", "COUNT"}, []string{"web", `{"app":"web"}`, "3"})
+	want := `{
+  "NAME": "web",
+  "LABELS": {
+    "app": "web"
+  },
+  "COUNT": "3"
+}`
+	if got != want {
+		t.Errorf("RecordJSON =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs are the helpers available in row templates
+var templateFuncs = template.FuncMap{
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"trim":    strings.TrimSpace,
+	"replace": replaceRegexp,
+	"quote":   ShellQuote,
+}
+
+// safeShellWord matches values that need no quoting in a shell command
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9_./:=@%+,-]+$`)
+
+// ParseTemplate parses a row template
+// Each row is a map keyed by header name, so {{.NAME}} is the NAME column and {{index . "NODE NAME"}} reaches
+// names that aren't identifiers. A name missing from the header is an error rather than an empty value.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("row").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// ExecuteTemplate renders the template once per row, in order, one line per row
+func ExecuteTemplate(tmpl *template.Template, t Table) (string, error) {
+	var lines []string
+	for _, row := range t.Rows {
+		fields := make(map[string]string, len(t.Header))
+		for i, name := range t.Header {
+			if i < len(row) {
+				fields[name] = row[i]
+			} else {
+				fields[name] = ""
+			}
+		}
+
+		var b strings.Builder
+		if err := tmpl.Execute(&b, fields); err != nil {
+			return "", fmt.Errorf("template: %w", err)
+		}
+		// A template ending with a newline still gives one line per row
+		lines = append(lines, strings.TrimSuffix(b.String(), "\n"))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// replaceRegexp replaces the matches of pattern in s; s comes last so it works in pipelines:
+// {{.NAME | replace "-[a-z0-9]+$" ""}}
+func replaceRegexp(pattern, replacement, s string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+	return re.ReplaceAllString(s, replacement), nil
+}
+
+// ShellQuote quotes s for a POSIX shell, leaving plain words as they are
+func ShellQuote(s string) string {
+	if safeShellWord.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExecuteTemplate(t *testing.T) {
+	table := Table{
+		Header: []string{"NAMESPACE", "NAME", "NODE NAME"},
+		Rows: [][]string{
+			{"prod", "web-7d9f", "n1"},
+			{"Dev Team", "it's", "n2"},
+		},
+	}
+
+	tests := []struct {
+		template, want string
+	}{
+		{"{{.NAMESPACE}}/{{.NAME}}", "prod/web-7d9f\nDev Team/it's"},
+		{"kubectl -n {{.NAMESPACE | quote}} delete pod {{quote .NAME}}\n", "kubectl -n prod delete pod web-7d9f\nkubectl -n 'Dev Team' delete pod 'it'\\''s'"},
+		{`{{.NAME | replace "-[a-z0-9]+$" "" | upper}} {{index . "NODE NAME"}}`, "WEB n1\nIT'S n2"},
+		{"{{.NAMESPACE | lower | trim}}", "prod\ndev team"},
+	}
+	for _, tt := range tests {
+		tmpl, err := ParseTemplate(tt.template)
+		if err != nil {
+			t.Fatalf("ParseTemplate(%q): %v", tt.template, err)
+		}
+		got, err := ExecuteTemplate(tmpl, table)
+		if err != nil {
+			t.Fatalf("ExecuteTemplate(%q): %v", tt.template, err)
+		}
+		if got != tt.want {
+			t.Errorf("ExecuteTemplate(%q) =\n%q\nwant\n%q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	if _, err := ParseTemplate("{{.NAME"); err == nil {
+		t.Error("expected a parse error")
+	}
+
+	table := Table{Header: []string{"NAME"}, Rows: [][]string{{"web"}}}
+	for _, text := range []string{"{{.NAMESPACE}}", `{{replace "(" "" .NAME}}`} {
+		tmpl, err := ParseTemplate(text)
+		if err != nil {
+			t.Fatalf("ParseTemplate(%q): %v", text, err)
+		}
+		if _, err := ExecuteTemplate(tmpl, table); err == nil || !strings.HasPrefix(err.Error(), "template:") {
+			t.Errorf("ExecuteTemplate(%q) error = %v", text, err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is a compression format recognized by its magic bytes
+type Compression int
+
+const (
+	Uncompressed Compression = iota
+	Gzip
+	Zstd
+	Bzip2
+)
+
+// magic is the leading bytes of a stream in a compression format
+type magic struct {
+	prefix      []byte
+	compression Compression
+}
+
+// magics lists the leading bytes of the compressed streams recognized
+var magics = append([]magic{
+	{[]byte{0x1f, 0x8b}, Gzip},
+	{[]byte{0x28, 0xb5, 0x2f, 0xfd}, Zstd},
+}, bzip2Magics()...)
+
+// bzip2Magics lists the starts of a bzip2 stream: "BZh", the block size 1-9, then the magic of the first block,
+// or of the end of the stream when it is empty, so text that merely starts with "BZh" isn't taken for bzip2
+func bzip2Magics() []magic {
+	var list []magic
+	for size := byte('1'); size <= '9'; size++ {
+		for _, block := range []string{"1AY&SY", "\x17\x72\x45\x38\x50\x90"} {
+			list = append(list, magic{append([]byte{'B', 'Z', 'h', size}, block...), Bzip2})
+		}
+	}
+	return list
+}
+
+// String returns the format name
+func (c Compression) String() string {
+	switch c {
+	case Gzip:
+		return "gzip"
+	case Zstd:
+		return "zstd"
+	case Bzip2:
+		return "bzip2"
+	}
+	return "uncompressed"
+}
+
+// Decompress detects gzip, zstd and bzip2 input by its magic bytes and decompresses it on the fly
+// Anything else is returned unchanged. The input is read only as far as it could still be compressed,
+// usually a single byte, so this doesn't wait on streams that are still being written.
+func Decompress(r io.Reader) (io.ReadCloser, Compression, error) {
+	buffered := bufio.NewReader(r)
+	compression := detect(buffered)
+
+	switch compression {
+	case Gzip:
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, compression, fmt.Errorf("error reading gzip input: %w", err)
+		}
+		return gz, compression, nil
+	case Zstd:
+		zr, err := zstd.NewReader(buffered)
+		if err != nil {
+			return nil, compression, fmt.Errorf("error reading zstd input: %w", err)
+		}
+		return zr.IOReadCloser(), compression, nil
+	case Bzip2:
+		return io.NopCloser(bzip2.NewReader(buffered)), compression, nil
+	}
+	return io.NopCloser(buffered), compression, nil
+}
+
+// detect peeks at the input one byte at a time until a magic matches or none can match anymore
+func detect(r *bufio.Reader) Compression {
+	for n := 1; ; n++ {
+		// A short or empty input simply isn't compressed
+		head, err := r.Peek(n)
+		if err != nil {
+			return Uncompressed
+		}
+		partial := false
+		for _, m := range magics {
+			if bytes.HasPrefix(head, m.prefix) {
+				return m.compression
+			}
+			if bytes.HasPrefix(m.prefix, head) {
+				partial = true
+			}
+		}
+		if !partial {
+			return Uncompressed
+		}
+	}
+}
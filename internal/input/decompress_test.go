@@ -0,0 +1,104 @@
+package input
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const table = "NAME  STATUS\nweb   Running\n"
+
+func TestDecompress(t *testing.T) {
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write([]byte(table))
+	gw.Close()
+
+	var zst bytes.Buffer
+	zw, err := zstd.NewWriter(&zst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw.Write([]byte(table))
+	zw.Close()
+
+	// The standard library has no bzip2 encoder: this is table compressed with bzip2 -9
+	bz := []byte("\x42\x5a\x68\x39\x31\x41\x59\x26\x53\x59\xde\x27\x4c\xa1\x00\x00\x05\xd7\x80\x00\x10\x40\x00\x22\x03\x1e\x00\x12\xa1\x02\x80\x20\x00\x31\x43\x4d\x30\x00\x44\xd1\xa6\x9b\x53\x13\x24\x33\xa2\x99\x37\x40\x1b\x73\x08\xb1\x74\x54\x77\x6f\xf1\x77\x24\x53\x85\x09\x0d\xe2\x74\xca\x10")
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  Compression
+	}{
+		{"plain", []byte(table), Uncompressed},
+		{"gzip", gz.Bytes(), Gzip},
+		{"zstd", zst.Bytes(), Zstd},
+		{"bzip2", bz, Bzip2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, compression, err := Decompress(bytes.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Decompress() error: %v", err)
+			}
+			defer r.Close()
+			if compression != tt.want {
+				t.Errorf("compression = %v, want %v", compression, tt.want)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("read error: %v", err)
+			}
+			if string(got) != table {
+				t.Errorf("decompressed %q, want %q", got, table)
+			}
+		})
+	}
+}
+
+func TestDecompressShortInput(t *testing.T) {
+	r, compression, err := Decompress(bytes.NewReader([]byte("x")))
+	if err != nil || compression != Uncompressed {
+		t.Fatalf("Decompress() = %v, %v", compression, err)
+	}
+	if got, _ := io.ReadAll(r); string(got) != "x" {
+		t.Errorf("read %q, want %q", got, "x")
+	}
+}
+
+func TestDecompressTextStartingLikeBzip2(t *testing.T) {
+	for _, text := range []string{"BZh", "BZh9 rows\n", "BZh91AY&SX\n"} {
+		r, compression, err := Decompress(bytes.NewReader([]byte(text)))
+		if err != nil || compression != Uncompressed {
+			t.Fatalf("Decompress(%q) = %v, %v", text, compression, err)
+		}
+		if got, _ := io.ReadAll(r); string(got) != text {
+			t.Errorf("read %q, want %q", got, text)
+		}
+	}
+}
+
+func TestDecompressDoesNotWaitForMoreInput(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	go pw.Write([]byte("NAME\n"))
+
+	done := make(chan Compression)
+	go func() {
+		_, compression, _ := Decompress(pr)
+		done <- compression
+	}()
+	select {
+	case compression := <-done:
+		if compression != Uncompressed {
+			t.Errorf("compression = %v, want %v", compression, Uncompressed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Decompress() waited for more input than a short line")
+	}
+}
@@ -0,0 +1,111 @@
+package input
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"tablefy/internal/parser"
+)
+
+// replacement is shown in place of bytes that can't be displayed
+const replacement = "�"
+
+// Reader reads lines of any length and sanitizes them for display
+// Unlike bufio.Scanner there is no token limit: a single multi-megabyte line is read whole
+type Reader struct {
+	r              *bufio.Reader
+	line           int // Number of the last line read, starting at 1
+	malformed      int // Lines that needed sanitizing
+	firstMalformed int // Number of the first line that needed sanitizing
+}
+
+// NewReader creates a Reader reading from r
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReaderSize(r, 64*1024)}
+}
+
+// ReadLine returns the next line, sanitized and without its line ending, and the number of raw bytes it took
+// A last line without a trailing newline is returned normally; io.EOF is returned once nothing is left
+func (r *Reader) ReadLine() (line string, size int, err error) {
+	raw, err := r.r.ReadString('\n')
+	if raw == "" && err != nil {
+		return "", 0, err
+	}
+	if err != nil && err != io.EOF {
+		return "", len(raw), err
+	}
+
+	r.line++
+	line, ok := Sanitize(strings.TrimSuffix(strings.TrimSuffix(raw, "\n"), "\r"))
+	if !ok {
+		if r.malformed == 0 {
+			r.firstMalformed = r.line
+		}
+		r.malformed++
+	}
+	return line, len(raw), nil
+}
+
+// Malformed returns how many lines needed sanitizing and the number of the first one (0 when none did)
+func (r *Reader) Malformed() (count, first int) {
+	return r.malformed, r.firstMalformed
+}
+
+// Sanitize makes a line safe to display: invalid UTF-8 and control characters (NUL, backspace,
+// carriage returns, stray ESC bytes...) are replaced with U+FFFD. Tabs separate columns and
+// well-formed escape sequences carry colors, so both are kept.
+// ok is false when anything had to be replaced.
+func Sanitize(line string) (clean string, ok bool) {
+	if isClean(line) {
+		return line, true
+	}
+
+	var b strings.Builder
+	b.Grow(len(line))
+	ok = true
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			if n := parser.EscapeLength(line[i:]); n > 0 {
+				b.WriteString(line[i : i+n])
+				i += n
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(line[i:])
+		if (r == utf8.RuneError && size == 1) || isControl(r) {
+			b.WriteString(replacement)
+			ok = false
+		} else {
+			b.WriteString(line[i : i+size])
+		}
+		i += size
+	}
+	return b.String(), ok
+}
+
+// SanitizeText sanitizes every line of a complete text, e.g. the output of a command
+func SanitizeText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i], _ = Sanitize(strings.TrimSuffix(line, "\r"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isClean reports whether a line can be used as is: valid UTF-8 without control characters or escapes
+func isClean(line string) bool {
+	for _, r := range line {
+		if r == utf8.RuneError || isControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isControl reports whether r is a control character other than tab
+func isControl(r rune) bool {
+	return (r < 0x20 && r != '\t') || (r >= 0x7f && r < 0xa0)
+}
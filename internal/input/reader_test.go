@@ -0,0 +1,80 @@
+package input
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReadLineHasNoLengthLimit(t *testing.T) {
+	long := strings.Repeat("x", 1<<20) // Well past bufio.Scanner's 64 KiB token limit
+	r := NewReader(strings.NewReader("NAME  VALUE\r\nblob  " + long + "\nlast"))
+
+	var lines []string
+	total := 0
+	for {
+		line, size, err := r.ReadLine()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadLine() error: %v", err)
+		}
+		lines = append(lines, line)
+		total += size
+	}
+
+	if len(lines) != 3 || lines[0] != "NAME  VALUE" || len(lines[1]) != len(long)+6 || lines[2] != "last" {
+		t.Errorf("unexpected lines (count %d)", len(lines))
+	}
+	if want := len("NAME  VALUE\r\nblob  ") + len(long) + len("\nlast"); total != want {
+		t.Errorf("read %d bytes, want %d", total, want)
+	}
+	if count, _ := r.Malformed(); count != 0 {
+		t.Errorf("Malformed() = %d, want 0", count)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		ok    bool
+	}{
+		{"plain", "web-1  Running", "web-1  Running", true},
+		{"tabs kept", "a\tb", "a\tb", true},
+		{"colors kept", "\x1b[32mok\x1b[0m", "\x1b[32mok\x1b[0m", true},
+		{"NUL", "a\x00b", "a�b", false},
+		{"invalid UTF-8", "caf\xe9", "caf�", false},
+		{"stray ESC", "a\x1bb", "a�b", false},
+		{"backspace", "ab\bc", "ab�c", false},
+		{"C1 control", "a\u009bb", "a�b", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Sanitize(tt.input)
+			if got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if ok != tt.ok {
+				t.Errorf("Sanitize(%q) ok = %v, want %v", tt.input, ok, tt.ok)
+			}
+		})
+	}
+}
+
+func TestReaderReportsMalformedLines(t *testing.T) {
+	r := NewReader(strings.NewReader("NAME\nok\nbad\x00\nfine\nbad\xff\n"))
+	for {
+		if _, _, err := r.ReadLine(); err != nil {
+			break
+		}
+	}
+
+	count, first := r.Malformed()
+	if count != 2 || first != 3 {
+		t.Errorf("Malformed() = %d, %d; want 2, 3", count, first)
+	}
+}
@@ -0,0 +1,191 @@
+package join
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Kind selects which unmatched rows a join keeps
+type Kind int
+
+const (
+	Inner Kind = iota // Only rows whose key is in both tables
+	Left              // Every row of the left table
+	Full              // Every row of both tables
+)
+
+// kindNames maps kinds to the names used in flags and prompts
+var kindNames = map[Kind]string{
+	Inner: "inner",
+	Left:  "left",
+	Full:  "full",
+}
+
+// String returns the kind name
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+// ParseKind returns the kind with the given name
+func ParseKind(name string) (Kind, error) {
+	for kind, n := range kindNames {
+		if n == strings.ToLower(name) {
+			return kind, nil
+		}
+	}
+	return Inner, fmt.Errorf("unknown join type %q (use inner, left or full)", name)
+}
+
+// Options describes a join
+type Options struct {
+	LeftKey     string // Key column of the left table
+	RightKey    string // Key column of the right table
+	Kind        Kind
+	LeftPrefix  string // Prefix for left columns whose name is also used in the right table; empty keeps them as is
+	RightPrefix string // Prefix for right columns whose name is also used in the left table
+}
+
+// ParseOn parses a join key specification: LEFT=RIGHT, or NAME when both columns have the same name
+func ParseOn(on string) (left, right string, err error) {
+	left, right, found := strings.Cut(on, "=")
+	if !found {
+		right = left
+	}
+	left, right = strings.TrimSpace(left), strings.TrimSpace(right)
+	if left == "" || right == "" {
+		return "", "", fmt.Errorf("invalid join key %q (use LEFT=RIGHT or NAME)", on)
+	}
+	return left, right, nil
+}
+
+// Join combines two tables (header at index 0) on their key columns
+// The result has the left columns followed by the right ones; the right key column is merged into the left one.
+// Rows keep the left table's order, with unmatched right rows (full joins) at the end.
+// A key matching several right rows produces one row per match.
+func Join(left, right [][]string, opts Options) ([][]string, error) {
+	if len(left) == 0 || len(right) == 0 {
+		return nil, fmt.Errorf("nothing to join: both tables need a header")
+	}
+	li := columnIndex(left[0], opts.LeftKey)
+	if li < 0 {
+		return nil, fmt.Errorf("column %q not found in the left table", opts.LeftKey)
+	}
+	ri := columnIndex(right[0], opts.RightKey)
+	if ri < 0 {
+		return nil, fmt.Errorf("column %q not found in the right table", opts.RightKey)
+	}
+
+	// Right columns other than the key, and the names both tables use
+	var rightCols []int
+	for i := range right[0] {
+		if i != ri {
+			rightCols = append(rightCols, i)
+		}
+	}
+	leftNames := make(map[string]bool, len(left[0]))
+	for _, name := range left[0] {
+		leftNames[name] = true
+	}
+	conflicts := make(map[string]bool)
+	for _, i := range rightCols {
+		if leftNames[right[0][i]] {
+			conflicts[right[0][i]] = true
+		}
+	}
+
+	header := make([]string, 0, len(left[0])+len(rightCols))
+	for i, name := range left[0] {
+		if conflicts[name] && opts.LeftPrefix != "" && i != li {
+			name = opts.LeftPrefix + "." + name
+		}
+		header = append(header, name)
+	}
+	for _, i := range rightCols {
+		name := right[0][i]
+		if conflicts[name] {
+			name = opts.RightPrefix + "." + name
+		}
+		header = append(header, name)
+	}
+
+	// Index the right rows by key
+	byKey := make(map[string][]int)
+	for r := 1; r < len(right); r++ {
+		key := cell(right[r], ri)
+		byKey[key] = append(byKey[key], r)
+	}
+
+	result := [][]string{header}
+	matched := make(map[int]bool)
+	for l := 1; l < len(left); l++ {
+		leftRow := padded(left[l], len(left[0]))
+		matches := byKey[cell(left[l], li)]
+		if len(matches) == 0 {
+			if opts.Kind != Inner {
+				result = append(result, append(leftRow, make([]string, len(rightCols))...))
+			}
+			continue
+		}
+		for _, r := range matches {
+			matched[r] = true
+			row := append([]string(nil), leftRow...)
+			for _, i := range rightCols {
+				row = append(row, cell(right[r], i))
+			}
+			result = append(result, row)
+		}
+	}
+
+	if opts.Kind == Full {
+		for r := 1; r < len(right); r++ {
+			if matched[r] {
+				continue
+			}
+			row := make([]string, len(left[0]))
+			row[li] = cell(right[r], ri)
+			for _, i := range rightCols {
+				row = append(row, cell(right[r], i))
+			}
+			result = append(result, row)
+		}
+	}
+	return result, nil
+}
+
+// columnIndex returns the index of the named column, or -1
+func columnIndex(header []string, name string) int {
+	for i, h := range header {
+		if h == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// cell returns the value at col, or "" for short rows
+func cell(row []string, col int) string {
+	if col < len(row) {
+		return row[col]
+	}
+	return ""
+}
+
+// padded returns a copy of row with exactly width cells
+func padded(row []string, width int) []string {
+	out := make([]string, width)
+	copy(out, row)
+	return out
+}
+
+// PrefixFor returns the column prefix used for an input file: its name without directory and extensions
+func PrefixFor(path string) string {
+	if path == "-" {
+		return "stdin"
+	}
+	name := filepath.Base(path)
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
@@ -0,0 +1,117 @@
+package join
+
+import (
+	"reflect"
+	"testing"
+)
+
+var pods = [][]string{
+	{"NAME", "STATUS", "NODE"},
+	{"web-1", "Running", "node-a"},
+	{"web-2", "Running", "node-b"},
+	{"db", "Pending", "node-x"},
+}
+
+var nodes = [][]string{
+	{"NAME", "STATUS", "ROLES"},
+	{"node-a", "Ready", "worker"},
+	{"node-b", "NotReady", "worker"},
+	{"node-c", "Ready", "control-plane"},
+}
+
+func TestJoinKinds(t *testing.T) {
+	// The right key (NAME) is merged into NODE, so only STATUS conflicts
+	header := []string{"NAME", "pods.STATUS", "NODE", "nodes.STATUS", "ROLES"}
+	tests := []struct {
+		kind Kind
+		want [][]string
+	}{
+		{Inner, [][]string{
+			header,
+			{"web-1", "Running", "node-a", "Ready", "worker"},
+			{"web-2", "Running", "node-b", "NotReady", "worker"},
+		}},
+		{Left, [][]string{
+			header,
+			{"web-1", "Running", "node-a", "Ready", "worker"},
+			{"web-2", "Running", "node-b", "NotReady", "worker"},
+			{"db", "Pending", "node-x", "", ""},
+		}},
+		{Full, [][]string{
+			header,
+			{"web-1", "Running", "node-a", "Ready", "worker"},
+			{"web-2", "Running", "node-b", "NotReady", "worker"},
+			{"db", "Pending", "node-x", "", ""},
+			{"", "", "node-c", "Ready", "control-plane"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String(), func(t *testing.T) {
+			got, err := Join(pods, nodes, Options{LeftKey: "NODE", RightKey: "NAME", Kind: tt.kind, LeftPrefix: "pods", RightPrefix: "nodes"})
+			if err != nil {
+				t.Fatalf("Join() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Join() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinKeepsLeftNamesWithoutPrefix(t *testing.T) {
+	got, err := Join(pods, nodes, Options{LeftKey: "NODE", RightKey: "NAME", RightPrefix: "nodes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"NAME", "STATUS", "NODE", "nodes.STATUS", "ROLES"}
+	if !reflect.DeepEqual(got[0], want) {
+		t.Errorf("header = %q, want %q", got[0], want)
+	}
+}
+
+func TestJoinDuplicateKeys(t *testing.T) {
+	right := [][]string{{"NODE", "EVENT"}, {"node-a", "Pulled"}, {"node-a", "Started"}}
+	got, err := Join(pods, right, Options{LeftKey: "NODE", RightKey: "NODE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[1][3] != "Pulled" || got[2][3] != "Started" {
+		t.Errorf("expected one row per match, got %q", got)
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	if _, err := Join(pods, nodes, Options{LeftKey: "MISSING", RightKey: "NAME"}); err == nil {
+		t.Error("expected an error for a missing left key")
+	}
+	if _, err := Join(pods, nodes, Options{LeftKey: "NODE", RightKey: "MISSING"}); err == nil {
+		t.Error("expected an error for a missing right key")
+	}
+}
+
+func TestParseOn(t *testing.T) {
+	if l, r, err := ParseOn("NODE=NAME"); err != nil || l != "NODE" || r != "NAME" {
+		t.Errorf("ParseOn(NODE=NAME) = %q, %q, %v", l, r, err)
+	}
+	if l, r, err := ParseOn("NAME"); err != nil || l != "NAME" || r != "NAME" {
+		t.Errorf("ParseOn(NAME) = %q, %q, %v", l, r, err)
+	}
+	if _, _, err := ParseOn("=NAME"); err == nil {
+		t.Error("expected an error for an empty left key")
+	}
+}
+
+func TestPrefixFor(t *testing.T) {
+	tests := map[string]string{
+		"/tmp/nodes.txt":   "nodes",
+		"pods-wide.csv.gz": "pods-wide",
+		"-":                "stdin",
+		".hidden":          ".hidden",
+	}
+	for path, want := range tests {
+		if got := PrefixFor(path); got != want {
+			t.Errorf("PrefixFor(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
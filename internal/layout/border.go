@@ -0,0 +1,85 @@
+package layout
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BorderStyle identifies how the table is framed
+type BorderStyle int
+
+const (
+	BorderNormal    BorderStyle = iota // Box drawing lines around every cell
+	BorderRounded                      // Like normal, with rounded corners
+	BorderThick                        // Heavy box drawing lines
+	BorderDouble                       // Double box drawing lines
+	BorderASCII                        // +, - and | only, for limited terminals and fonts
+	BorderUnderline                    // No frame, only a line under the header
+	BorderCompact                      // No lines at all, a single space between columns
+)
+
+// borderStyleNames maps each style to its command line name
+var borderStyleNames = []string{"normal", "rounded", "thick", "double", "ascii", "underline", "compact"}
+
+// Frame describes the space a table style spends outside cell content
+type Frame struct {
+	Outer     int // Columns used by the left and right borders together
+	Separator int // Columns used between two adjacent cells
+	Padding   int // Padding columns inside each cell (left + right)
+	Rules     int // Lines used by horizontal borders: top, under the header and bottom
+}
+
+// DefaultFrame is the frame of the normal border style: "│ a │ b │" costs numCols*3 + 1 columns
+var DefaultFrame = BorderNormal.Frame()
+
+// Overhead returns the number of columns a table with numCols columns spends outside cell content
+func (f Frame) Overhead(numCols int) int {
+	if numCols <= 0 {
+		return f.Outer
+	}
+	return f.Outer + (numCols-1)*f.Separator + numCols*f.Padding
+}
+
+// ParseBorderStyle converts a style name (as used on the command line) to a BorderStyle
+func ParseBorderStyle(name string) (BorderStyle, error) {
+	for i, n := range borderStyleNames {
+		if strings.EqualFold(n, name) {
+			return BorderStyle(i), nil
+		}
+	}
+	return BorderNormal, fmt.Errorf("unknown border style %q (available: %s)", name, strings.Join(borderStyleNames, ", "))
+}
+
+// BorderStyleNames returns the names of all border styles
+func BorderStyleNames() []string {
+	return append([]string(nil), borderStyleNames...)
+}
+
+// String returns the name of the style
+func (b BorderStyle) String() string {
+	if b < 0 || int(b) >= len(borderStyleNames) {
+		return "unknown"
+	}
+	return borderStyleNames[b]
+}
+
+// Next returns the following style, wrapping around after the last one
+func (b BorderStyle) Next() BorderStyle {
+	return BorderStyle((int(b) + 1) % len(borderStyleNames))
+}
+
+// Frame returns the overhead of the style
+func (b BorderStyle) Frame() Frame {
+	switch b {
+	case BorderUnderline:
+		// Columns are separated by two spaces, no outer frame, a line under the header
+		return Frame{Outer: 0, Separator: 2, Padding: 0, Rules: 1}
+	case BorderCompact:
+		// Columns are separated by a single space, no outer frame and no lines
+		return Frame{Outer: 0, Separator: 1, Padding: 0, Rules: 0}
+	default:
+		// One border character on each side, one between cells and one space of padding on each side of a cell;
+		// lines above, under the header and below
+		return Frame{Outer: 2, Separator: 1, Padding: 2, Rules: 3}
+	}
+}
@@ -0,0 +1,98 @@
+package layout
+
+import (
+	"testing"
+)
+
+func TestFrameOverhead(t *testing.T) {
+	tests := []struct {
+		style    BorderStyle
+		numCols  int
+		expected int
+	}{
+		{BorderNormal, 3, 3*3 + 1},
+		{BorderRounded, 5, 5*3 + 1},
+		{BorderASCII, 1, 1*3 + 1},
+		{BorderUnderline, 3, 4},
+		{BorderCompact, 3, 2},
+		{BorderCompact, 1, 0},
+	}
+
+	for _, tt := range tests {
+		got := tt.style.Frame().Overhead(tt.numCols)
+		if got != tt.expected {
+			t.Errorf("%s overhead for %d columns = %d, want %d", tt.style, tt.numCols, got, tt.expected)
+		}
+	}
+}
+
+func TestParseBorderStyle(t *testing.T) {
+	for _, name := range BorderStyleNames() {
+		style, err := ParseBorderStyle(name)
+		if err != nil {
+			t.Errorf("ParseBorderStyle(%q) returned error: %v", name, err)
+		}
+		if style.String() != name {
+			t.Errorf("ParseBorderStyle(%q).String() = %q", name, style.String())
+		}
+	}
+
+	if _, err := ParseBorderStyle("fancy"); err == nil {
+		t.Error("Expected error for unknown border style")
+	}
+}
+
+func TestBorderStyleNextWrapsAround(t *testing.T) {
+	if BorderCompact.Next() != BorderNormal {
+		t.Errorf("Next after compact should wrap to normal, got %s", BorderCompact.Next())
+	}
+	if BorderNormal.Next() != BorderRounded {
+		t.Errorf("Next after normal should be rounded, got %s", BorderNormal.Next())
+	}
+}
+
+// TestCalculateColumnWidthsForFrame_CompactReclaimsSpace verifies that compact mode
+// hands the border and padding columns over to the data
+func TestCalculateColumnWidthsForFrame_CompactReclaimsSpace(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "STATUS", "AGE"},
+		{"app1", "running", "1d"},
+	}
+	termWidth := 80
+
+	normal := CalculateColumnWidthsForFrame(rows, termWidth, BorderNormal.Frame())
+	compact := CalculateColumnWidthsForFrame(rows, termWidth, BorderCompact.Frame())
+
+	normalTotal, compactTotal := 0, 0
+	for i := range normal {
+		normalTotal += normal[i]
+		compactTotal += compact[i]
+	}
+
+	if normalTotal != termWidth-(3*3+1) {
+		t.Errorf("Normal total = %d, want %d", normalTotal, termWidth-(3*3+1))
+	}
+	if compactTotal != termWidth-2 {
+		t.Errorf("Compact total = %d, want %d", compactTotal, termWidth-2)
+	}
+}
+
+// TestGetVisibleRowsForFrame verifies that styles without horizontal lines show more rows
+func TestGetVisibleRowsForFrame(t *testing.T) {
+	tests := []struct {
+		style BorderStyle
+		want  int
+	}{
+		{BorderNormal, 18},
+		{BorderUnderline, 20},
+		{BorderCompact, 21},
+	}
+	for _, tt := range tests {
+		if got := GetVisibleRowsForFrame(24, tt.style.Frame()); got != tt.want {
+			t.Errorf("GetVisibleRowsForFrame(24, %s) = %d, want %d", tt.style, got, tt.want)
+		}
+	}
+	if got := GetVisibleRowsForZoomFrame(24, BorderCompact.Frame()); got != GetVisibleRowsForZoom(24)+3 {
+		t.Errorf("GetVisibleRowsForZoomFrame(24, compact) = %d, want 3 more rows than normal", got)
+	}
+}
@@ -12,8 +12,8 @@ func CalculateFullColumnWidths(rows [][]string) []int {
 	// Calculate the maximum width of each column
 	for _, row := range rows {
 		for i := 0; i < len(row) && i < numCols; i++ {
-			if len(row[i]) > widths[i] {
-				widths[i] = len(row[i])
+			if w := DisplayWidth(row[i]); w > widths[i] {
+				widths[i] = w
 			}
 		}
 	}
@@ -21,8 +21,14 @@ func CalculateFullColumnWidths(rows [][]string) []int {
 	return widths
 }
 
-// CalculateColumnWidths calculates the optimal width for each column
+// CalculateColumnWidths calculates the optimal width for each column using the normal border frame
 func CalculateColumnWidths(rows [][]string, termWidth int) []int {
+	return CalculateColumnWidthsForFrame(rows, termWidth, DefaultFrame)
+}
+
+// CalculateColumnWidthsForFrame calculates the optimal width for each column
+// The frame determines how much of the terminal width is spent on borders and padding
+func CalculateColumnWidthsForFrame(rows [][]string, termWidth int, frame Frame) []int {
 	if len(rows) == 0 {
 		return nil
 	}
@@ -34,8 +40,8 @@ func CalculateColumnWidths(rows [][]string, termWidth int) []int {
 	// Calculate the maximum width of each column
 	for _, row := range rows {
 		for i := 0; i < len(row) && i < numCols; i++ {
-			if len(row[i]) > widths[i] {
-				widths[i] = len(row[i])
+			if w := DisplayWidth(row[i]); w > widths[i] {
+				widths[i] = w
 			}
 		}
 	}
@@ -55,7 +61,7 @@ func CalculateColumnWidths(rows [][]string, termWidth int) []int {
 	}
 
 	// Calculate space needed for borders and padding
-	overhead := numCols*3 + 1
+	overhead := frame.Overhead(numCols)
 	availableWidth := termWidth - overhead
 
 	// Calculate total width of columns
@@ -136,9 +142,14 @@ func CalculateColumnWidths(rows [][]string, termWidth int) []int {
 	return adjustedWidths
 }
 
-// CalculateColumnWidthsWithAutoExpand calculates column widths with auto-expand support
+// CalculateColumnWidthsWithAutoExpand calculates column widths with auto-expand support using the normal border frame
 // When a column has focus and contains truncated cells, it gets expanded to full width
 func CalculateColumnWidthsWithAutoExpand(rows [][]string, termWidth int, focusedColumn int, currentWidths []int) []int {
+	return CalculateColumnWidthsWithAutoExpandForFrame(rows, termWidth, focusedColumn, currentWidths, DefaultFrame)
+}
+
+// CalculateColumnWidthsWithAutoExpandForFrame is CalculateColumnWidthsWithAutoExpand for a specific border frame
+func CalculateColumnWidthsWithAutoExpandForFrame(rows [][]string, termWidth int, focusedColumn int, currentWidths []int, frame Frame) []int {
 	if len(rows) == 0 || focusedColumn < 0 || focusedColumn >= len(currentWidths) {
 		return currentWidths
 	}
@@ -160,7 +171,7 @@ func CalculateColumnWidthsWithAutoExpand(rows [][]string, termWidth int, focused
 	extraNeeded := fullWidth - currentWidths[focusedColumn]
 
 	// Calculate space needed for borders and padding
-	overhead := len(currentWidths)*3 + 1
+	overhead := frame.Overhead(len(currentWidths))
 	totalCurrentWidth := 0
 	for _, w := range currentWidths {
 		totalCurrentWidth += w
@@ -35,3 +35,14 @@ func GetVisibleRowsForZoom(termHeight int) int {
 	}
 	return visibleRows
 }
+
+// GetVisibleRowsForFrame calculates how many rows are visible for a table drawn with the frame
+// Styles with fewer horizontal lines than the normal border show that many more rows
+func GetVisibleRowsForFrame(termHeight int, frame Frame) int {
+	return GetVisibleRows(termHeight + DefaultFrame.Rules - frame.Rules)
+}
+
+// GetVisibleRowsForZoomFrame calculates how many rows are visible in zoom mode for a table drawn with the frame
+func GetVisibleRowsForZoomFrame(termHeight int, frame Frame) int {
+	return GetVisibleRowsForZoom(termHeight + DefaultFrame.Rules - frame.Rules)
+}
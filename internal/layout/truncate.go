@@ -1,14 +1,20 @@
 package layout
 
-// TruncateCell truncates a cell to the maximum width
+import (
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TruncateCell truncates a cell to the maximum display width
+// Cuts happen on grapheme boundaries, so multi-byte characters are never split;
+// a wide character that does not fit is dropped, leaving the cell one cell narrower
 func TruncateCell(cell string, maxWidth int) string {
-	if len(cell) <= maxWidth {
+	if DisplayWidth(cell) <= maxWidth {
 		return cell
 	}
 	if maxWidth <= 3 {
-		return cell[:maxWidth]
+		return ansi.Truncate(cell, maxWidth, "")
 	}
-	return cell[:maxWidth-3] + "..."
+	return ansi.Truncate(cell, maxWidth, "...")
 }
 
 // TruncateRows truncates rows according to column widths
@@ -2,10 +2,7 @@ package layout
 
 // IsTruncated checks if a cell has been truncated
 func IsTruncated(cell string, maxWidth int) bool {
-	if maxWidth <= 3 {
-		return len(cell) > maxWidth
-	}
-	return len(cell) > maxWidth
+	return DisplayWidth(cell) > maxWidth
 }
 
 // ColumnHasTruncatedCells checks if any cell in a column is truncated
@@ -32,8 +29,8 @@ func ColumnFullWidths(rows [][]string) []int {
 	// Calculate the maximum width of each column
 	for _, row := range rows {
 		for i := 0; i < len(row) && i < numCols; i++ {
-			if len(row[i]) > widths[i] {
-				widths[i] = len(row[i])
+			if w := DisplayWidth(row[i]); w > widths[i] {
+				widths[i] = w
 			}
 		}
 	}
@@ -45,8 +42,10 @@ func ColumnFullWidths(rows [][]string) []int {
 func GetRequiredWidthForColumn(rows [][]string, columnIndex int) int {
 	maxWidth := 0
 	for _, row := range rows {
-		if columnIndex < len(row) && len(row[columnIndex]) > maxWidth {
-			maxWidth = len(row[columnIndex])
+		if columnIndex < len(row) {
+			if w := DisplayWidth(row[columnIndex]); w > maxWidth {
+				maxWidth = w
+			}
 		}
 	}
 	return maxWidth
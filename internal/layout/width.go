@@ -0,0 +1,22 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DisplayWidth returns the number of terminal cells a string occupies
+// Wide East Asian characters and emoji count as two cells, combining marks and
+// zero-width joiner sequences are measured as a single grapheme cluster
+func DisplayWidth(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// PadRight pads a string with spaces until it occupies width terminal cells
+func PadRight(s string, width int) string {
+	if gap := width - DisplayWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
@@ -0,0 +1,82 @@
+package layout
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		cell     string
+		expected int
+	}{
+		{"hello", 5},
+		{"café", 4},       // precomposed é
+		{"cafe\u0301", 4}, // e + combining acute accent
+		{"日本語", 6},        // CJK characters are two cells wide
+		{"✅ ok", 5},       // emoji presentation is two cells wide
+		{"👩‍💻", 2},        // ZWJ sequence renders as a single emoji
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.cell); got != tt.expected {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", tt.cell, got, tt.expected)
+		}
+	}
+}
+
+func TestTruncateCellUnicode(t *testing.T) {
+	tests := []struct {
+		cell     string
+		maxWidth int
+		expected string
+	}{
+		{"hello world", 8, "hello..."},
+		{"héllo wörld", 8, "héllo..."},
+		{"日本語のポッド名", 9, "日本語..."},
+		// A wide character that would straddle the limit is dropped rather than split
+		{"日本語のポッド名", 8, "日本..."},
+		{"✅✅✅✅", 3, "✅"},
+		{"👩‍💻👩‍💻👩‍💻", 5, "👩‍💻..."},
+		{"short", 10, "short"},
+	}
+
+	for _, tt := range tests {
+		got := TruncateCell(tt.cell, tt.maxWidth)
+		if got != tt.expected {
+			t.Errorf("TruncateCell(%q, %d) = %q, want %q", tt.cell, tt.maxWidth, got, tt.expected)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("TruncateCell(%q, %d) produced invalid UTF-8: %q", tt.cell, tt.maxWidth, got)
+		}
+		if DisplayWidth(got) > tt.maxWidth {
+			t.Errorf("TruncateCell(%q, %d) = %q is %d cells wide", tt.cell, tt.maxWidth, got, DisplayWidth(got))
+		}
+	}
+}
+
+func TestCalculateFullColumnWidthsUnicode(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "STATUS"},
+		{"ポッド", "✅ Running"},
+		{"café", "ok"},
+	}
+
+	widths := CalculateFullColumnWidths(rows)
+	if widths[0] != 6 {
+		t.Errorf("Width[0] = %d, want 6 (three wide characters)", widths[0])
+	}
+	if widths[1] != 10 {
+		t.Errorf("Width[1] = %d, want 10", widths[1])
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	if got := PadRight("日本", 6); got != "日本  " {
+		t.Errorf("PadRight = %q, want %q", got, "日本  ")
+	}
+	if got := PadRight("toolong", 3); got != "toolong" {
+		t.Errorf("PadRight should not truncate, got %q", got)
+	}
+}
@@ -1,33 +1,104 @@
 package model
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/layout"
 )
 
 // Update handles messages
+// The preview pane follows the row under the cursor, whatever moved it
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	if next, ok := next.(Model); ok && next.Previewing() {
+		return next.followPreview(cmd)
+	}
+	return next, cmd
+}
+
+// update dispatches a message to its handler
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
 	case tea.WindowSizeMsg:
-		m.TermWidth = msg.Width
-		m.TermHeight = msg.Height
+		m.resize(msg.Width, msg.Height)
+	case previewDebounceMsg:
+		return m.handlePreviewDebounce(msg)
+	case previewResultMsg:
+		return m.handlePreviewResult(msg)
+	case watchTickMsg:
+		return m.handleWatchTick(msg)
+	case RefreshMsg:
+		return m.handleRefresh(msg)
+	case streamBatchMsg:
+		return m.handleStreamBatch(msg)
+	case loadTickMsg:
+		return m.handleLoadTick()
+	case joinLoadedMsg:
+		return m.handleJoinLoaded(msg)
+	case exportDoneMsg:
+		m.StatusMessage = msg.status
+		return m, nil
+	case actionDoneMsg:
+		return m.handleActionDone(msg)
+	case pipeDoneMsg:
+		return m.handlePipeDone(msg)
+	case openDoneMsg:
+		if msg.err != nil {
+			m.StatusMessage = fmt.Sprintf("Open failed: %v", msg.err)
+		}
+		return m, nil
 	}
 	return m, nil
 }
 
 // handleKeyPress processes keyboard input
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// A status message is shown until the next key
+	m.StatusMessage = ""
+
+	// An open prompt takes every key
+	if m.Prompt != nil {
+		return m.handlePromptInput(msg)
+	}
+
 	// Handle FilterView input separately
 	if m.ViewMode == FilterView {
 		return m.handleFilterViewInput(msg)
 	}
 
+	// The list of edits takes every key while shown
+	if m.ShowChanges {
+		return m.handleChangesKey(msg.String())
+	}
+
+	// The key after y picks what to copy
+	if m.pendingYank {
+		return m.handleYank(msg.String())
+	}
+
+	// Edit mode commands take precedence over the other keys
+	if m.Editing {
+		if next, cmd, ok := m.handleEditKey(msg.String()); ok {
+			return next, cmd
+		}
+	}
+
+	// Keys bound to row actions take precedence over the built-in keys
+	if a, ok := m.actionForKey(msg.String()); ok {
+		return m.runAction(a)
+	}
+
 	// Handle special key types first (more efficient than string comparison)
 	switch msg.Type {
 	case tea.KeyPgUp:
 		// Page Up (Re Pág) - scroll up by page size
+		m.Follow = false
 		pageSize := m.GetPageSize()
+		m.CursorRow = max(m.CursorPosition()-pageSize, 0)
 		m.ScrollOffset -= pageSize
 		if m.ScrollOffset < 0 {
 			m.ScrollOffset = 0
@@ -37,6 +108,7 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Page Down - scroll down by page size
 		pageSize := m.GetPageSize()
 		maxScroll := m.GetMaxScroll()
+		m.CursorRow = m.CursorPosition() + pageSize
 		m.ScrollOffset += pageSize
 		if m.ScrollOffset > maxScroll {
 			m.ScrollOffset = maxScroll
@@ -46,7 +118,12 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "ctrl+c", "esc":
-		return m, tea.Quit
+		if msg.String() == "ctrl+c" && m.StreamOpen {
+			// Stop loading and keep browsing what was read so far
+			m.cancelLoad()
+			return m, nil
+		}
+		return m.quit()
 	case "q":
 		if m.ViewMode == ZoomView {
 			// Exit zoom mode
@@ -54,9 +131,9 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.ScrollOffset = 0 // Reset scroll when exiting zoom
 			return m, nil
 		}
-		return m, tea.Quit
+		return m.quit()
 	case "f", "F":
-		if m.ViewMode == NormalView {
+		if m.ViewMode == NormalView && len(m.Rows) > 0 {
 			// Enter filter mode
 			m.ViewMode = FilterView
 			m.FilterColumnIndex = m.CurrentColumn
@@ -73,10 +150,14 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.ScrollOffset = 0
 			return m, nil
 		}
-	case "o", "O":
+	case "o":
 		// Export visible table and quit
-		m.ExportData = m.GetExportData()
-		return m, tea.Quit
+		return m.exportOnQuit()
+	case "O":
+		// Ask for the export format and destination, then keep working
+		if len(m.Rows) > 0 {
+			m.openExportPrompt()
+		}
 	case "left", "h":
 		if m.ViewMode == NormalView && m.CurrentColumn > 0 {
 			m.CurrentColumn--
@@ -86,15 +167,19 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.CurrentColumn++
 		}
 	case "up", "k":
-		// Scroll up
-		if m.ScrollOffset > 0 {
-			m.ScrollOffset--
-		}
+		// Move the row cursor up, leaving follow mode
+		m.Follow = false
+		m.moveCursor(-1)
 	case "down", "j":
-		// Scroll down
-		maxScroll := m.GetMaxScroll()
-		if m.ScrollOffset < maxScroll {
-			m.ScrollOffset++
+		// Move the row cursor down, scrolling at the bottom of the page
+		m.moveCursor(1)
+	case "x":
+		// Select the current row for actions
+		m.toggleRowSelection()
+	case "a":
+		// Pick an action to run on the current or selected rows
+		if len(m.Actions) > 0 {
+			m.openActionMenu()
 		}
 	case "s", "S":
 		// Toggle selection of current column
@@ -105,6 +190,99 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.SelectedColumns[m.CurrentColumn] = true
 			}
 		}
+	case "m", "M":
+		// Toggle heatmap coloring of numeric columns
+		m.Heatmap = !m.Heatmap
+	case "b", "B":
+		// Cycle through border styles
+		m.Border = m.Border.Next()
+	case "P":
+		// Show or hide the preview pane; without one, P pauses watch mode like p
+		if m.PreviewCommand != "" {
+			m.togglePreview()
+		} else if m.Watching() {
+			return m, m.toggleWatchPause()
+		}
+	case "shift+down":
+		// Scroll the preview output
+		if m.Previewing() {
+			m.scrollPreview(1)
+		}
+	case "shift+up":
+		if m.Previewing() {
+			m.scrollPreview(-1)
+		}
+	case "p":
+		// Pause or resume watch mode
+		if m.Watching() {
+			return m, m.toggleWatchPause()
+		}
+	case "r", "R":
+		// Refresh the watched command right away
+		if m.Watching() {
+			return m, m.refreshNow()
+		}
+	case "G":
+		// Follow the stream: stick to the last row as rows arrive
+		if m.Streaming() {
+			m.toggleFollow()
+		}
+	case "T":
+		// Write the template exports render every row with
+		if len(m.Rows) > 0 {
+			m.openTemplatePrompt()
+		}
+	case "E":
+		// Enter edit mode to change cells and delete rows or columns
+		m.toggleEditing()
+	case "~":
+		// Show the changes made in edit mode
+		m.toggleChanges()
+	case "y":
+		// Copy the cell, row, column or selection to the clipboard, picked by the next key
+		m.startYank()
+	case "v":
+		// Read the focused cell in full in the pager or editor
+		return m.openCell()
+	case "V":
+		// Read every column of the current row in the pager or editor
+		return m.openRow()
+	case "|":
+		// Pipe the visible table through a shell command, showing its output as the table
+		if len(m.Rows) > 0 {
+			m.openPipePrompt()
+		}
+	case "u":
+		// Go back to the table before the last pipe
+		m.undoPipe()
+	case "J":
+		// Join another input into the table
+		if m.ViewMode == NormalView && len(m.Rows) > 0 {
+			m.openPrompt(PromptJoin, "Join with", "[inner|left|full] [LEFT=RIGHT | =RIGHT] FILE")
+		}
+	case "[":
+		// Step back to the previous snapshot
+		m.stepHistory(-1)
+	case "]":
+		// Step forward to the next snapshot, back to live after the newest
+		m.stepHistory(1)
+	case "=":
+		// Mark the snapshot on screen as the base for diff exports
+		m.markDiffBase()
+	case "d":
+		// Cycle the rows shown when comparing two tables
+		if m.Diffing() {
+			m.cycleDiffFilter()
+		}
+	case "D":
+		// Export the differences between the compared tables in the export format, and quit
+		if m.Diffing() {
+			return m.exportDiffOnQuit(m.DiffTable())
+		}
+		// Export the diff between the base and the snapshot on screen the same way
+		if len(m.History) > 1 {
+			return m.exportDiffOnQuit(m.SnapshotDiffTable())
+		}
 	case "enter", " ":
 		if m.ViewMode == NormalView && len(m.SelectedColumns) > 0 {
 			// Enter zoom mode with selected columns
@@ -117,11 +295,8 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // GetMaxScroll calculates the maximum scroll offset
 func (m Model) GetMaxScroll() int {
-	// Account for header, borders, and help text (approximately 6 lines)
-	visibleRows := m.TermHeight - 6
-	if visibleRows < 1 {
-		visibleRows = 1
-	}
+	// Account for header, borders, and help text (approximately 6 lines with the normal border)
+	visibleRows := layout.GetVisibleRowsForFrame(m.TermHeight, m.Border.Frame())
 
 	// Determine total data rows based on filter status
 	var dataRows int
@@ -130,6 +305,9 @@ func (m Model) GetMaxScroll() int {
 	} else {
 		dataRows = len(m.Rows) - 1 // All rows except header
 	}
+	if m.GhostsShown() {
+		dataRows += len(m.Ghosts) // Removed rows are shown after the table
+	}
 
 	maxScroll := dataRows - visibleRows
 	if maxScroll < 0 {
@@ -140,18 +318,14 @@ func (m Model) GetMaxScroll() int {
 
 // GetPageSize calculates how many rows fit in a page for pgup/pgdn
 func (m Model) GetPageSize() int {
-	// Account for header, borders, and help text (approximately 6 lines)
-	pageSize := m.TermHeight - 6
-	if pageSize < 1 {
-		pageSize = 1
-	}
-	return pageSize
+	// Account for header, borders, and help text (approximately 6 lines with the normal border)
+	return layout.GetVisibleRowsForFrame(m.TermHeight, m.Border.Frame())
 }
 
 // GetMaxFilterScroll calculates the maximum scroll offset for filter view
 func (m Model) GetMaxFilterScroll() int {
-	// Account for header, borders, filter input, and help text (approximately 7 lines)
-	visibleRows := m.TermHeight - 7
+	// Account for header, borders, filter input, and help text (approximately 7 lines with the normal border)
+	visibleRows := layout.GetVisibleRowsForFrame(m.TermHeight, m.Border.Frame()) - 1
 	if visibleRows < 1 {
 		visibleRows = 1
 	}
@@ -172,6 +346,7 @@ func (m Model) handleFilterViewInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
 	case tea.KeyPgUp:
 		// Page Up (Re Pág) - scroll up by page size in filter view
+		m.Follow = false
 		pageSize := m.GetPageSize()
 		m.FilterScrollOffset -= pageSize
 		if m.FilterScrollOffset < 0 {
@@ -208,7 +383,8 @@ func (m Model) handleFilterViewInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.FilterScrollOffset = 0
 		}
 	case "up", "k":
-		// Scroll up in filter view
+		// Scroll up in filter view, leaving follow mode
+		m.Follow = false
 		if m.FilterScrollOffset > 0 {
 			m.FilterScrollOffset--
 		}
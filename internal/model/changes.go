@@ -0,0 +1,179 @@
+package model
+
+import "tablefy/internal/diff"
+
+// DefaultHighlightRefreshes is how many refreshes a change stays highlighted by default
+const DefaultHighlightRefreshes = 3
+
+// RowState describes how a row compares to the previous snapshot
+type RowState int
+
+const (
+	RowUnchanged RowState = iota
+	RowAdded              // Row was not in the previous snapshot
+	RowRemoved            // Row disappeared, kept as a struck-through ghost
+)
+
+// ghostRow is a row that disappeared from the snapshot but is still shown for a few refreshes
+type ghostRow struct {
+	key       string
+	cells     map[string]string // Values by column name, so ghosts survive column changes
+	remaining int
+}
+
+// changeTracker remembers what changed between snapshots and for how many more refreshes to show it
+type changeTracker struct {
+	cells  map[string]map[string]int // Row key → column name → remaining refreshes
+	added  map[string]int            // Row key → remaining refreshes
+	ghosts []ghostRow
+}
+
+// age counts one refresh down for every highlight and drops the expired ones
+func (c *changeTracker) age() {
+	for key, cols := range c.cells {
+		for col, n := range cols {
+			if n <= 1 {
+				delete(cols, col)
+			} else {
+				cols[col] = n - 1
+			}
+		}
+		if len(cols) == 0 {
+			delete(c.cells, key)
+		}
+	}
+	for key, n := range c.added {
+		if n <= 1 {
+			delete(c.added, key)
+		} else {
+			c.added[key] = n - 1
+		}
+	}
+	var ghosts []ghostRow
+	for _, g := range c.ghosts {
+		if g.remaining > 1 {
+			g.remaining--
+			ghosts = append(ghosts, g)
+		}
+	}
+	c.ghosts = ghosts
+}
+
+// compare records the differences between two snapshots (header at index 0, no ghost rows)
+func (c *changeTracker) compare(oldRows, newRows [][]string, keyColumns []string, refreshes int) {
+	if c.cells == nil {
+		c.cells = make(map[string]map[string]int)
+		c.added = make(map[string]int)
+	}
+	c.age()
+	if len(oldRows) == 0 || len(newRows) == 0 || refreshes <= 0 {
+		return
+	}
+
+	// Rows that came back are no longer ghosts
+	present := make(map[string]bool, len(newRows))
+	for _, key := range diff.Keys(newRows, keyColumns)[1:] {
+		present[key] = true
+	}
+	var ghosts []ghostRow
+	for _, g := range c.ghosts {
+		if !present[g.key] {
+			ghosts = append(ghosts, g)
+		}
+	}
+
+	for _, row := range diff.Compare(oldRows, newRows, keyColumns).Rows {
+		switch row.Kind {
+		case diff.Added:
+			c.added[row.Key] = refreshes
+		case diff.Changed:
+			if c.cells[row.Key] == nil {
+				c.cells[row.Key] = make(map[string]int)
+			}
+			for _, name := range row.Columns {
+				c.cells[row.Key][name] = refreshes
+			}
+		case diff.Removed:
+			ghosts = append(ghosts, ghostRow{key: row.Key, cells: row.Cells, remaining: refreshes})
+		}
+	}
+	c.ghosts = ghosts
+}
+
+// applySnapshot swaps in a new snapshot, recording what changed since the previous one
+// While browsing the history the past snapshot stays on screen; changes are still tracked
+func (m *Model) applySnapshot(rows, styled [][]string) {
+	m.changes.compare(m.liveRows(), rows, m.RowKey, m.HighlightRefreshes)
+	if m.Browsing() {
+		return
+	}
+	m.showSnapshot(rows, styled)
+}
+
+// showSnapshot displays a snapshot, keeping the interactive state
+// The live snapshot gets the rows that disappeared as Ghosts, which the view shows after the table
+func (m *Model) showSnapshot(rows, styled [][]string) {
+	m.ReplaceRows(rows, styled)
+	m.rowKeys = diff.Keys(m.Rows, m.RowKey)
+
+	m.Ghosts = nil
+	if m.Browsing() || len(m.Rows) == 0 {
+		return
+	}
+	header := m.Rows[0]
+	for _, g := range m.changes.ghosts {
+		row := make([]string, len(header))
+		for i, name := range header {
+			row[i] = g.cells[name]
+		}
+		m.Ghosts = append(m.Ghosts, row)
+	}
+}
+
+// RowStateAt returns whether the row at rowIdx was added or removed in a recent refresh,
+// or between the two tables being compared
+// Past snapshots are shown without highlights
+func (m Model) RowStateAt(rowIdx int) RowState {
+	if m.Diffing() {
+		row, _ := m.diffRowAt(rowIdx)
+		switch row.Kind {
+		case diff.Added:
+			return RowAdded
+		case diff.Removed:
+			return RowRemoved
+		}
+		return RowUnchanged
+	}
+	if m.Browsing() || rowIdx <= 0 || rowIdx >= len(m.rowKeys) {
+		return RowUnchanged
+	}
+	if m.changes.added[m.rowKeys[rowIdx]] > 0 {
+		return RowAdded
+	}
+	return RowUnchanged
+}
+
+// CellChanged reports whether the cell at rowIdx, col changed in a recent refresh, between the compared tables
+// or in edit mode
+func (m Model) CellChanged(rowIdx, col int) bool {
+	if m.CellEdited(rowIdx, col) {
+		return true
+	}
+	if m.Diffing() {
+		row, ok := m.diffRowAt(rowIdx)
+		if !ok || col < 0 || col >= len(m.Rows[0]) {
+			return false
+		}
+		_, changed := row.Old[m.Rows[0][col]]
+		return changed
+	}
+	if m.Browsing() || rowIdx <= 0 || rowIdx >= len(m.rowKeys) || len(m.Rows) == 0 || col < 0 || col >= len(m.Rows[0]) {
+		return false
+	}
+	return m.changes.cells[m.rowKeys[rowIdx]][m.Rows[0][col]] > 0
+}
+
+// GhostsShown reports whether the view shows the Ghosts after the table: they are hidden while filtering
+func (m Model) GhostsShown() bool {
+	return len(m.Ghosts) > 0 && len(m.FilteredRowIndices) == 0 && m.ViewMode != FilterView
+}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplySnapshotHighlightsChanges(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "STATUS"},
+		{"web", "Running"},
+		{"db", "Running"},
+		{"cache", "Running"},
+	}
+	m := New(rows, 80, 24)
+	m.HighlightRefreshes = 2
+
+	m.applySnapshot([][]string{
+		{"NAME", "STATUS"},
+		{"web", "Running"},
+		{"db", "CrashLoopBackOff"},
+		{"queue", "Pending"},
+	}, nil)
+
+	if m.RowStateAt(1) != RowUnchanged || m.CellChanged(1, 1) {
+		t.Errorf("unchanged row web is highlighted")
+	}
+	if !m.CellChanged(2, 1) || m.CellChanged(2, 0) {
+		t.Errorf("only STATUS of db should be marked changed")
+	}
+	if m.RowStateAt(3) != RowAdded {
+		t.Errorf("RowStateAt(queue) = %v, want RowAdded", m.RowStateAt(3))
+	}
+
+	// The removed row is kept apart as a ghost, shown after the table
+	if len(m.Rows) != 4 || len(m.Ghosts) != 1 || m.Ghosts[0][0] != "cache" || !m.GhostsShown() {
+		t.Fatalf("expected cache as a ghost row, got rows %v and ghosts %v", m.Rows, m.Ghosts)
+	}
+
+	// Exports never include ghosts
+	if strings.Contains(m.GetExportData(), "cache") {
+		t.Errorf("export contains the ghost row:\n%s", m.GetExportData())
+	}
+}
+
+func TestHighlightsExpire(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "STATUS"},
+		{"web", "Running"},
+		{"db", "Running"},
+	}
+	m := New(rows, 80, 24)
+	m.HighlightRefreshes = 2
+
+	changed := [][]string{
+		{"NAME", "STATUS"},
+		{"web", "Pending"},
+	}
+	m.applySnapshot(changed, nil)
+	if !m.CellChanged(1, 1) || len(m.Ghosts) != 1 {
+		t.Fatalf("expected web changed and db ghosted after the first refresh")
+	}
+
+	// Still highlighted one refresh later
+	m.applySnapshot(changed, nil)
+	if !m.CellChanged(1, 1) || len(m.Ghosts) != 1 {
+		t.Errorf("highlights expired too early")
+	}
+
+	// Gone after HighlightRefreshes refreshes
+	m.applySnapshot(changed, nil)
+	if m.CellChanged(1, 1) || len(m.Rows) != 2 || len(m.Ghosts) != 0 {
+		t.Errorf("highlights still shown after expiry: rows %v", m.Rows)
+	}
+}
+
+func TestGhostRowDroppedWhenRowReturns(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "STATUS"},
+		{"web", "Running"},
+		{"db", "Running"},
+	}
+	m := New(rows, 80, 24)
+
+	m.applySnapshot([][]string{{"NAME", "STATUS"}, {"web", "Running"}}, nil)
+	m.applySnapshot(rows, nil)
+
+	if len(m.Rows) != 3 || len(m.Ghosts) != 0 {
+		t.Errorf("returned row still has a ghost: rows %v", m.Rows)
+	}
+}
+
+func TestGhostRowsHiddenWhileFiltering(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "CPU"},
+		{"web", "10"},
+		{"web-batch", "900"},
+	}
+	m := New(rows, 80, 24)
+	m.applySnapshot(rows[:2], nil)
+	if len(m.Ghosts) != 1 || !m.GhostsShown() {
+		t.Fatalf("expected the batch row as a shown ghost, got ghosts %v", m.Ghosts)
+	}
+
+	// The ghost is no filter result and the table rows stay the live ones
+	m = typeKeys(m, keyMsg("f"), keyMsg("w"))
+	if m.GhostsShown() || len(m.FilteredRowIndices) != 1 || m.FilteredRowIndices[0] != 1 {
+		t.Errorf("filter gave %v with ghosts shown %v, want only the live row", m.FilteredRowIndices, m.GhostsShown())
+	}
+}
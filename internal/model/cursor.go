@@ -0,0 +1,110 @@
+package model
+
+import (
+	"tablefy/internal/diff"
+	"tablefy/internal/layout"
+)
+
+// visibleDataRows returns how many data rows the current view shows at once
+func (m Model) visibleDataRows() int {
+	if m.ViewMode == ZoomView {
+		return layout.GetVisibleRowsForZoomFrame(m.TermHeight, m.Border.Frame())
+	}
+	return layout.GetVisibleRowsForFrame(m.TermHeight, m.Border.Frame())
+}
+
+// CursorPosition returns the position of the row cursor among the shown data rows (DataRowIndices), -1 without rows
+// The cursor stays on the visible page: scrolling past it moves it along
+func (m Model) CursorPosition() int {
+	n := len(m.DataRowIndices())
+	if n == 0 {
+		return -1
+	}
+	pos := max(m.CursorRow, m.ScrollOffset)
+	pos = min(pos, m.ScrollOffset+m.visibleDataRows()-1)
+	return max(min(pos, n-1), 0)
+}
+
+// CurrentRowIndex returns the index in Rows of the row under the cursor
+func (m Model) CurrentRowIndex() (int, bool) {
+	pos := m.CursorPosition()
+	if pos < 0 {
+		return -1, false
+	}
+	return m.DataRowIndices()[pos], true
+}
+
+// moveCursor moves the row cursor by delta rows, scrolling to keep it on the page
+func (m *Model) moveCursor(delta int) {
+	n := len(m.DataRowIndices())
+	if n == 0 {
+		return
+	}
+	pos := max(min(m.CursorPosition()+delta, n-1), 0)
+	m.CursorRow = pos
+
+	page := m.visibleDataRows()
+	if pos < m.ScrollOffset {
+		m.ScrollOffset = pos
+	}
+	if pos >= m.ScrollOffset+page {
+		m.ScrollOffset = pos - page + 1
+	}
+}
+
+// toggleRowSelection selects or unselects the row under the cursor and moves to the next one
+func (m *Model) toggleRowSelection() {
+	rowIdx, ok := m.CurrentRowIndex()
+	if !ok {
+		return
+	}
+	if m.SelectedRows[rowIdx] {
+		delete(m.SelectedRows, rowIdx)
+	} else {
+		if m.SelectedRows == nil {
+			m.SelectedRows = make(map[int]bool)
+		}
+		m.SelectedRows[rowIdx] = true
+	}
+	m.moveCursor(1)
+}
+
+// TargetRows returns the indices in Rows an action applies to: the selected rows in display order, or the current row
+func (m Model) TargetRows() []int {
+	var rows []int
+	if len(m.SelectedRows) > 0 {
+		for _, rowIdx := range m.DataRowIndices() {
+			if m.SelectedRows[rowIdx] {
+				rows = append(rows, rowIdx)
+			}
+		}
+		if len(rows) > 0 {
+			return rows
+		}
+	}
+	if rowIdx, ok := m.CurrentRowIndex(); ok {
+		rows = append(rows, rowIdx)
+	}
+	return rows
+}
+
+// remapSelectedRows keeps the row selection on the same rows, by key, when the rows are replaced
+func (m *Model) remapSelectedRows(oldRows, newRows [][]string) {
+	if len(m.SelectedRows) == 0 {
+		return
+	}
+	oldKeys := diff.Keys(oldRows, m.RowKey)
+	selected := make(map[string]bool)
+	for rowIdx := range m.SelectedRows {
+		if rowIdx < len(oldKeys) {
+			selected[oldKeys[rowIdx]] = true
+		}
+	}
+
+	m.SelectedRows = make(map[int]bool)
+	for i, key := range diff.Keys(newRows, m.RowKey) {
+		if i > 0 && selected[key] {
+			m.SelectedRows[i] = true
+		}
+	}
+}
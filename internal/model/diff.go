@@ -0,0 +1,115 @@
+package model
+
+import (
+	"tablefy/internal/diff"
+	"tablefy/internal/export"
+)
+
+// DiffColumn is the marker column shown in front of the columns of a table comparison
+const DiffColumn = "DIFF"
+
+// DiffFilter selects which rows of a table comparison are shown
+type DiffFilter int
+
+const (
+	DiffAll     DiffFilter = iota // Every row, changed or not
+	DiffChanges                   // Added, removed and changed rows
+	DiffAdded                     // Only added rows
+	DiffRemoved                   // Only removed rows
+	DiffChanged                   // Only changed rows
+)
+
+// diffFilterKinds lists the row kinds each filter shows
+var diffFilterKinds = map[DiffFilter][]diff.Kind{
+	DiffAll:     {diff.Added, diff.Removed, diff.Changed, diff.Unchanged},
+	DiffChanges: {diff.Added, diff.Removed, diff.Changed},
+	DiffAdded:   {diff.Added},
+	DiffRemoved: {diff.Removed},
+	DiffChanged: {diff.Changed},
+}
+
+// String returns the filter name shown in the help line
+func (f DiffFilter) String() string {
+	switch f {
+	case DiffChanges:
+		return "changes"
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffChanged:
+		return "changed"
+	default:
+		return "all"
+	}
+}
+
+// SetDiff shows the comparison of two tables (from diff.CompareAll) as the table:
+// a marker column followed by every column, with changed cells reading "old → new"
+func (m *Model) SetDiff(result diff.Result) {
+	m.tableDiff = &result
+	m.showDiff()
+}
+
+// Diffing reports whether the model shows the comparison of two tables
+func (m Model) Diffing() bool {
+	return m.tableDiff != nil
+}
+
+// DiffResult returns the rows of the comparison allowed by the current filter
+func (m Model) DiffResult() diff.Result {
+	if m.tableDiff == nil {
+		return diff.Result{}
+	}
+	return m.tableDiff.Filter(diffFilterKinds[m.DiffFilter]...)
+}
+
+// DiffCounts returns how many rows were added, removed and changed between the two tables
+func (m Model) DiffCounts() (added, removed, changed int) {
+	if m.tableDiff == nil {
+		return 0, 0, 0
+	}
+	return m.tableDiff.Count(diff.Added), m.tableDiff.Count(diff.Removed), m.tableDiff.Count(diff.Changed)
+}
+
+// DiffTable returns the differences allowed by the current filter as a table for the exporters,
+// with a CHANGE column and the previous values of changed columns in "old." columns
+func (m Model) DiffTable() export.Table {
+	rows := m.DiffResult().Filter(diff.Added, diff.Removed, diff.Changed).Table()
+	return export.Table{Header: rows[0], Rows: rows[1:]}
+}
+
+// showDiff lays out the rows of the comparison allowed by the filter, keeping the interactive state
+func (m *Model) showDiff() {
+	result := m.DiffResult()
+	rows := [][]string{append([]string{DiffColumn}, result.Header...)}
+	diffRows := []diff.Row{{}}
+	for _, row := range result.Rows {
+		line := []string{row.Kind.Marker()}
+		for _, name := range result.Header {
+			value := row.Cells[name]
+			if old, ok := row.Old[name]; ok {
+				value = old + " → " + value
+			}
+			line = append(line, value)
+		}
+		rows = append(rows, line)
+		diffRows = append(diffRows, row)
+	}
+	m.diffRows = diffRows
+	m.ReplaceRows(rows, nil)
+}
+
+// cycleDiffFilter shows the next set of rows: all, changes only, added, removed, changed
+func (m *Model) cycleDiffFilter() {
+	m.DiffFilter = (m.DiffFilter + 1) % (DiffChanged + 1)
+	m.showDiff()
+}
+
+// diffRowAt returns the comparison row shown at rowIdx
+func (m Model) diffRowAt(rowIdx int) (diff.Row, bool) {
+	if rowIdx <= 0 || rowIdx >= len(m.diffRows) {
+		return diff.Row{}, false
+	}
+	return m.diffRows[rowIdx], true
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"tablefy/internal/diff"
+	"tablefy/internal/export"
+)
+
+func newDiffModel() Model {
+	oldRows := [][]string{{"NAME", "STATUS"}, {"web", "Running"}, {"db", "Running"}, {"cache", "Running"}}
+	newRows := [][]string{{"NAME", "STATUS"}, {"web", "Running"}, {"db", "Failed"}, {"queue", "Pending"}}
+	m := New(nil, 80, 24)
+	m.SetDiff(diff.CompareAll(oldRows, newRows, []string{"NAME"}))
+	return m
+}
+
+func TestSetDiff(t *testing.T) {
+	m := newDiffModel()
+
+	want := [][]string{
+		{DiffColumn, "NAME", "STATUS"},
+		{" ", "web", "Running"},
+		{"~", "db", "Running → Failed"},
+		{"+", "queue", "Pending"},
+		{"-", "cache", "Running"},
+	}
+	if fmt.Sprint(m.Rows) != fmt.Sprint(want) {
+		t.Fatalf("Rows = %q, want %q", m.Rows, want)
+	}
+
+	if m.RowStateAt(1) != RowUnchanged || m.RowStateAt(3) != RowAdded || m.RowStateAt(4) != RowRemoved {
+		t.Error("rows should be marked by their diff kind")
+	}
+	if !m.CellChanged(2, 2) || m.CellChanged(2, 1) || m.CellChanged(1, 2) {
+		t.Error("only the changed STATUS cell should be highlighted")
+	}
+	if added, removed, changed := m.DiffCounts(); added != 1 || removed != 1 || changed != 1 {
+		t.Errorf("DiffCounts = %d %d %d, want 1 1 1", added, removed, changed)
+	}
+}
+
+func TestDiffFilterCycle(t *testing.T) {
+	m := newDiffModel()
+	m.CurrentColumn = 2
+
+	var shown []string
+	for i := 0; i < 5; i++ {
+		m = typeKeys(m, keyMsg("d"))
+		var markers []string
+		for _, row := range m.Rows[1:] {
+			markers = append(markers, row[0])
+		}
+		shown = append(shown, m.DiffFilter.String()+":"+strings.Join(markers, ""))
+	}
+
+	want := "changes:~+-,added:+,removed:-,changed:~,all: ~+-"
+	if got := strings.Join(shown, ","); got != want {
+		t.Errorf("filters = %q, want %q", got, want)
+	}
+	if m.CurrentColumn != 2 {
+		t.Errorf("focused column moved to %d while filtering", m.CurrentColumn)
+	}
+}
+
+func TestDiffReportExport(t *testing.T) {
+	m := newDiffModel()
+	m = typeKeys(m, keyMsg("d"), keyMsg("d"))
+
+	next, _ := m.Update(keyMsg("D"))
+	report := next.(Model).ExportData
+	if !strings.Contains(report, "added") || !strings.Contains(report, "queue") || strings.Contains(report, "cache") || strings.Contains(report, "web") {
+		t.Errorf("report should only list the added row:\n%s", report)
+	}
+}
+
+func TestDiffReportUsesExportFormat(t *testing.T) {
+	m := newDiffModel()
+	m.ExportFormat = export.CSV
+	m = typeKeys(m, keyMsg("d"))
+
+	next, _ := m.Update(keyMsg("D"))
+	report := next.(Model).ExportData
+	if !strings.HasPrefix(report, "CHANGE,") || !strings.Contains(report, "old.") {
+		t.Errorf("report should be CSV with CHANGE and old. columns:\n%s", report)
+	}
+}
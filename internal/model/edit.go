@@ -0,0 +1,393 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/diff"
+)
+
+// editState keeps the table as it was before the first edit, to show and revert the changes
+// Edits are made to Rows itself, so everything reading the table (exports, copies, pipes) sees them
+type editState struct {
+	rows      [][]string // Rows before any edit, header included
+	styled    [][]string
+	rowOrigin []int // Index in rows of every row of Rows
+	colOrigin []int // Index in the original header of every column of Rows
+}
+
+// EditChanges counts the changes made in edit mode
+type EditChanges struct {
+	Cells   int // Edited cells of the rows still there
+	Rows    int // Deleted rows
+	Columns int // Deleted columns
+}
+
+// Total returns the number of changes
+func (c EditChanges) Total() int {
+	return c.Cells + c.Rows + c.Columns
+}
+
+// toggleEditing enters or leaves edit mode; the changes stay when leaving
+func (m *Model) toggleEditing() {
+	if m.Editing {
+		m.Editing = false
+		return
+	}
+	switch {
+	case len(m.Rows) == 0 || m.ViewMode != NormalView:
+		return
+	case m.Watching():
+		m.StatusMessage = "Editing is not available in watch mode: refreshes would replace the changes"
+		return
+	case m.Diffing():
+		m.StatusMessage = "Editing is not available while comparing tables"
+		return
+	case m.StreamOpen:
+		m.StatusMessage = "Wait for the input to finish loading before editing"
+		return
+	}
+	m.Editing = true
+}
+
+// handleEditKey runs the edit mode commands; ok is false for keys edit mode leaves to the normal bindings
+func (m Model) handleEditKey(key string) (next tea.Model, cmd tea.Cmd, ok bool) {
+	switch key {
+	case "enter", "i":
+		m.openEditPrompt()
+	case "D":
+		m.deleteRows()
+	case "C":
+		m.deleteColumn()
+	case "r":
+		m.revertCell()
+	case "U":
+		if m.edits != nil {
+			m.openConfirm(PromptRevert, fmt.Sprintf("Revert all %d changes to the original table?", m.EditChanges().Total()))
+		}
+	case "E", "esc":
+		m.Editing = false
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// openEditPrompt asks for the new value of the focused cell, on every selected row when rows are selected
+func (m *Model) openEditPrompt() {
+	rows := m.TargetRows()
+	if len(rows) == 0 || m.CurrentColumn >= len(m.Rows[0]) {
+		return
+	}
+	name := m.Rows[0][m.CurrentColumn]
+	label := "Edit " + name
+	if len(rows) > 1 {
+		label = fmt.Sprintf("Set %s on %d rows", name, len(rows))
+	}
+	m.openPrompt(PromptEdit, label, "new value, empty to blank the cell")
+	m.Prompt.Input = cellAt(m.Rows[rows[0]], m.CurrentColumn)
+}
+
+// startEdits remembers the table before its first change
+func (m *Model) startEdits() {
+	if m.edits != nil {
+		return
+	}
+	edits := &editState{rows: m.Rows, styled: m.StyledRows}
+	for i := range m.Rows {
+		edits.rowOrigin = append(edits.rowOrigin, i)
+	}
+	for i := range m.Rows[0] {
+		edits.colOrigin = append(edits.colOrigin, i)
+	}
+	m.edits = edits
+}
+
+// setCells sets the focused column of the rows to value
+// The rows are copied first: the previous slices may still be held by the history or pipe steps
+func (m *Model) setCells(rowIndices []int, value string) {
+	m.startEdits()
+	rows := append([][]string(nil), m.Rows...)
+	var styled [][]string
+	if m.StyledRows != nil {
+		styled = append([][]string(nil), m.StyledRows...)
+	}
+	col := m.CurrentColumn
+	for _, rowIdx := range rowIndices {
+		rows[rowIdx] = setCell(rows[rowIdx], col, value)
+		if styled != nil && rowIdx < len(styled) {
+			styled[rowIdx] = setCell(styled[rowIdx], col, value)
+		}
+	}
+	m.setRows(rows, styled)
+}
+
+// setRows puts the edited table in place
+// Unlike ReplaceRows, nothing is matched by key or re-filtered: the callers keep the row and column indices in step
+func (m *Model) setRows(rows, styled [][]string) {
+	m.Rows = rows
+	m.StyledRows = styled
+	m.ScrollOffset = min(m.ScrollOffset, m.GetMaxScroll())
+}
+
+// setCell returns a copy of the row with the cell at col set to value, padding a short row
+func setCell(row []string, col int, value string) []string {
+	cells := make([]string, max(len(row), col+1))
+	copy(cells, row)
+	cells[col] = value
+	return cells
+}
+
+// applyEdit sets the focused cell of the current or selected rows to the answer of the edit prompt
+func (m Model) applyEdit(value string) (tea.Model, tea.Cmd) {
+	rows := m.TargetRows()
+	if len(rows) == 0 || m.CurrentColumn >= len(m.Rows[0]) {
+		return m, nil
+	}
+	m.setCells(rows, value)
+	if len(rows) > 1 {
+		m.StatusMessage = fmt.Sprintf("Set %s on %d rows", m.Rows[0][m.CurrentColumn], len(rows))
+	}
+	return m, nil
+}
+
+// revertCell puts back the original value of the focused cell on the current or selected rows
+func (m *Model) revertCell() {
+	if m.edits == nil || m.CurrentColumn >= len(m.Rows[0]) {
+		return
+	}
+	col := m.edits.colOrigin[m.CurrentColumn]
+	for _, rowIdx := range m.TargetRows() {
+		original := cellAt(m.edits.rows[m.edits.rowOrigin[rowIdx]], col)
+		if cellAt(m.Rows[rowIdx], m.CurrentColumn) != original {
+			m.setCells([]int{rowIdx}, original)
+		}
+	}
+}
+
+// deleteRows removes the current or selected rows
+func (m *Model) deleteRows() {
+	targets := m.TargetRows()
+	if len(targets) == 0 {
+		return
+	}
+	m.startEdits()
+	deleted := make(map[int]bool, len(targets))
+	for _, rowIdx := range targets {
+		deleted[rowIdx] = true
+	}
+
+	var rows, styled [][]string
+	var origin []int
+	moved := make([]int, len(m.Rows)) // New index of every row, -1 once deleted
+	for i, row := range m.Rows {
+		if deleted[i] {
+			moved[i] = -1
+			continue
+		}
+		moved[i] = len(rows)
+		rows = append(rows, row)
+		if m.StyledRows != nil && i < len(m.StyledRows) {
+			styled = append(styled, m.StyledRows[i])
+		}
+		origin = append(origin, m.edits.rowOrigin[i])
+	}
+	m.edits.rowOrigin = origin
+	m.SelectedRows = nil
+
+	// The filter keeps the rows it matched that are still there
+	if len(m.FilteredRowIndices) > 0 {
+		var filtered []int
+		for _, rowIdx := range m.FilteredRowIndices {
+			if moved[rowIdx] > 0 {
+				filtered = append(filtered, moved[rowIdx])
+			}
+		}
+		if len(filtered) == 0 {
+			m.ClearFilter()
+		}
+		m.FilteredRowIndices = filtered
+	}
+	m.setRows(rows, styled)
+	m.StatusMessage = fmt.Sprintf("Deleted %d rows (U: Revert all)", len(targets))
+}
+
+// deleteColumn removes the focused column; the last column is kept
+func (m *Model) deleteColumn() {
+	if len(m.Rows[0]) <= 1 || m.CurrentColumn >= len(m.Rows[0]) {
+		return
+	}
+	m.startEdits()
+	col := m.CurrentColumn
+	name := m.Rows[0][col]
+
+	without := func(row []string) []string {
+		if col >= len(row) {
+			return row
+		}
+		return append(append([]string(nil), row[:col]...), row[col+1:]...)
+	}
+	rows := make([][]string, len(m.Rows))
+	for i, row := range m.Rows {
+		rows[i] = without(row)
+	}
+	var styled [][]string
+	for _, row := range m.StyledRows {
+		styled = append(styled, without(row))
+	}
+	m.edits.colOrigin = append(append([]int(nil), m.edits.colOrigin[:col]...), m.edits.colOrigin[col+1:]...)
+
+	// Leave the focus on the column that took its place, and shift the columns after it
+	if m.CurrentColumn == len(rows[0]) {
+		m.CurrentColumn--
+	}
+	selected := make(map[int]bool, len(m.SelectedColumns))
+	for c := range m.SelectedColumns {
+		switch {
+		case c < col:
+			selected[c] = true
+		case c > col:
+			selected[c-1] = true
+		}
+	}
+	m.SelectedColumns = selected
+	switch {
+	case m.FilterColumnIndex == col:
+		m.ClearFilter()
+	case m.FilterColumnIndex > col:
+		m.FilterColumnIndex--
+	}
+	m.setRows(rows, styled)
+	m.StatusMessage = fmt.Sprintf("Deleted column %s (U: Revert all)", name)
+}
+
+// revertEdits puts back the table as it was before the first edit
+func (m *Model) revertEdits() {
+	if m.edits == nil {
+		return
+	}
+	edits := m.edits
+	m.edits = nil
+	m.SelectedRows = nil
+
+	// Rows and columns go back to their original indices
+	var filtered []int
+	for _, rowIdx := range m.FilteredRowIndices {
+		filtered = append(filtered, edits.rowOrigin[rowIdx])
+	}
+	m.FilteredRowIndices = filtered
+	selected := make(map[int]bool, len(m.SelectedColumns))
+	for c := range m.SelectedColumns {
+		selected[edits.colOrigin[c]] = true
+	}
+	m.SelectedColumns = selected
+	m.CurrentColumn = edits.colOrigin[m.CurrentColumn]
+	if m.FilterColumnIndex >= 0 {
+		m.FilterColumnIndex = edits.colOrigin[m.FilterColumnIndex]
+	}
+	m.setRows(edits.rows, edits.styled)
+	m.StatusMessage = "Reverted to the original table"
+}
+
+// forgetEdits makes the current table the original one, e.g. once it was piped or joined into a new table
+func (m *Model) forgetEdits() {
+	m.edits = nil
+	m.ShowChanges = false
+}
+
+// CellEdited reports whether the cell at rowIdx, col differs from the original table
+func (m Model) CellEdited(rowIdx, col int) bool {
+	if m.edits == nil || rowIdx <= 0 || rowIdx >= len(m.Rows) || col < 0 || col >= len(m.edits.colOrigin) {
+		return false
+	}
+	original := m.edits.rows[m.edits.rowOrigin[rowIdx]]
+	return cellAt(original, m.edits.colOrigin[col]) != cellAt(m.Rows[rowIdx], col)
+}
+
+// EditChanges counts the edited cells and the deleted rows and columns
+func (m Model) EditChanges() EditChanges {
+	if m.edits == nil {
+		return EditChanges{}
+	}
+	changes := EditChanges{
+		Rows:    len(m.edits.rows) - len(m.Rows),
+		Columns: len(m.edits.rows[0]) - len(m.edits.colOrigin),
+	}
+	for rowIdx := 1; rowIdx < len(m.Rows); rowIdx++ {
+		for col := range m.edits.colOrigin {
+			if m.CellEdited(rowIdx, col) {
+				changes.Cells++
+			}
+		}
+	}
+	return changes
+}
+
+// ChangeLines lists the changes against the original table, rows named by their key:
+// the deleted columns, then the deleted rows (-) and the edited cells (~) in table order
+func (m Model) ChangeLines() []string {
+	if m.edits == nil {
+		return nil
+	}
+	original := m.edits.rows
+	keys := diff.Keys(original, m.RowKey)
+
+	var lines []string
+	kept := make(map[int]bool, len(m.edits.colOrigin))
+	for _, col := range m.edits.colOrigin {
+		kept[col] = true
+	}
+	var columns []string
+	for col, name := range original[0] {
+		if !kept[col] {
+			columns = append(columns, name)
+		}
+	}
+	if len(columns) > 0 {
+		lines = append(lines, "Deleted columns: "+strings.Join(columns, ", "))
+	}
+
+	current := make(map[int]int, len(m.Rows))
+	for rowIdx, origin := range m.edits.rowOrigin {
+		current[origin] = rowIdx
+	}
+	for origin := 1; origin < len(original); origin++ {
+		rowIdx, ok := current[origin]
+		if !ok {
+			lines = append(lines, "- "+keys[origin])
+			continue
+		}
+		for col := range m.edits.colOrigin {
+			if m.CellEdited(rowIdx, col) {
+				old := cellAt(original[origin], m.edits.colOrigin[col])
+				lines = append(lines, fmt.Sprintf("~ %s %s: %q → %q", keys[origin], m.Rows[0][col], old, cellAt(m.Rows[rowIdx], col)))
+			}
+		}
+	}
+	return lines
+}
+
+// toggleChanges shows or hides the list of changes over the table
+func (m *Model) toggleChanges() {
+	if !m.ShowChanges && m.edits == nil {
+		m.StatusMessage = "No changes"
+		return
+	}
+	m.ShowChanges = !m.ShowChanges
+	m.ChangesScroll = 0
+}
+
+// handleChangesKey scrolls or closes the list of changes; it takes every key while shown
+func (m Model) handleChangesKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "~", "esc", "q":
+		m.ShowChanges = false
+	case "down", "j":
+		m.ChangesScroll = min(m.ChangesScroll+1, max(len(m.ChangeLines())-1, 0))
+	case "up", "k":
+		m.ChangesScroll = max(m.ChangesScroll-1, 0)
+	}
+	return m, nil
+}
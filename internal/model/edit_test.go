@@ -0,0 +1,171 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editCell answers the edit prompt of the focused cell with value
+func editCell(m Model, value string) Model {
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m.Prompt.Input = value
+	return typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestEditCells(t *testing.T) {
+	rows := [][]string{{"NAME", "STATUS", "AGE"}, {"web", "Running", "3d"}, {"db", "Pending", "1h"}, {"junk", "Unknown", "?"}}
+	m := New(rows, 80, 24)
+	m = typeKeys(m, keyMsg("E"))
+	if !m.Editing {
+		t.Fatal("E should enter edit mode")
+	}
+
+	// Enter edits the focused cell, starting from its value
+	m.CurrentColumn = 1
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Prompt == nil || m.Prompt.Kind != PromptEdit || m.Prompt.Input != "Running" {
+		t.Fatalf("Enter should open the edit prompt with the value, got %+v", m.Prompt)
+	}
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+	m = editCell(m, "Failed")
+	if m.Rows[1][1] != "Failed" || !m.CellEdited(1, 1) || !m.CellChanged(1, 1) || m.CellEdited(1, 0) {
+		t.Errorf("edit not applied or marked: %v", m.Rows[1])
+	}
+	if rows[1][1] != "Running" {
+		t.Error("the rows handed to the model were modified")
+	}
+
+	// With rows selected, the edit sets the column on all of them
+	m = typeKeys(m, keyMsg("j"), keyMsg("x"), keyMsg("x"))
+	m = editCell(m, "")
+	if m.Rows[2][1] != "" || m.Rows[3][1] != "" || !strings.Contains(m.StatusMessage, "2 rows") {
+		t.Errorf("selected rows not blanked: %v (status %q)", m.Rows, m.StatusMessage)
+	}
+	if got := m.EditChanges(); got != (EditChanges{Cells: 3}) {
+		t.Errorf("EditChanges = %+v, want 3 cells", got)
+	}
+
+	// r puts the original value back
+	m.SelectedRows = nil
+	m.CursorRow = 1
+	m = typeKeys(m, keyMsg("r"))
+	if m.Rows[2][1] != "Pending" || m.CellEdited(2, 1) {
+		t.Errorf("r should revert the cell, got %v", m.Rows[2])
+	}
+
+	// Exports use the edited table
+	if got, _ := m.Export("csv"); got != "NAME,STATUS,AGE\nweb,Failed,3d\ndb,Pending,1h\njunk,,?" {
+		t.Errorf("export = %q", got)
+	}
+
+	// Leaving edit mode keeps the changes
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Editing || m.Rows[1][1] != "Failed" {
+		t.Errorf("Esc should leave edit mode and keep the edits, editing %v", m.Editing)
+	}
+}
+
+func TestEditDeleteAndRevert(t *testing.T) {
+	rows := [][]string{{"NAME", "STATUS", "AGE"}, {"web", "Running", "3d"}, {"db", "Pending", "1h"}, {"junk", "Unknown", "?"}}
+	m := New(rows, 80, 24)
+	m = typeKeys(m, keyMsg("E"))
+
+	// Delete the last row and the STATUS column, then edit a cell after both
+	m = typeKeys(m, keyMsg("j"), keyMsg("j"), keyMsg("D"))
+	m.CurrentColumn = 1
+	m = typeKeys(m, keyMsg("C"))
+	if fmt.Sprint(m.Rows) != "[[NAME AGE] [web 3d] [db 1h]]" || m.CurrentColumn != 1 {
+		t.Fatalf("rows after deleting = %v, focus %d", m.Rows, m.CurrentColumn)
+	}
+	m = editCell(m, "2h")
+	if !m.CellEdited(2, 1) || m.CellEdited(1, 1) {
+		t.Error("edits should be compared with the original cell of the moved column")
+	}
+
+	if got := m.EditChanges(); got != (EditChanges{Cells: 1, Rows: 1, Columns: 1}) {
+		t.Errorf("EditChanges = %+v", got)
+	}
+	want := []string{"Deleted columns: STATUS", "~ db AGE: \"1h\" → \"2h\"", "- junk"}
+	if got := m.ChangeLines(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ChangeLines = %q, want %q", got, want)
+	}
+
+	// ~ shows the changes and takes the keys until closed
+	m = typeKeys(m, keyMsg("~"), keyMsg("D"))
+	if !m.ShowChanges || len(m.Rows) != 3 {
+		t.Errorf("~ should show the changes and keep the keys, rows %v", m.Rows)
+	}
+	m = typeKeys(m, keyMsg("~"))
+
+	// U reverts everything after confirming
+	m = typeKeys(m, keyMsg("U"))
+	if m.Prompt == nil || !m.Prompt.Confirm {
+		t.Fatal("U should ask before reverting")
+	}
+	m = typeKeys(m, keyMsg("y"))
+	if fmt.Sprint(m.Rows) != fmt.Sprint(rows) || m.EditChanges().Total() != 0 {
+		t.Errorf("rows after revert = %v", m.Rows)
+	}
+}
+
+func TestEditKeepsSelectionAndFilter(t *testing.T) {
+	rows := [][]string{{"NAME", "STATUS"}, {"web", "Running"}, {"db", "Running"}, {"cache", "Pending"}}
+	m := New(rows, 80, 24)
+	m.FilterColumnIndex = 1
+	m.FilteredRowIndices = ApplyFuzzyFilter(m.Rows, 1, "run")
+	m = typeKeys(m, keyMsg("E"), keyMsg("x"), keyMsg("x"))
+
+	// Renaming the key column of the selected rows keeps them selected and filtered
+	m = editCell(m, "renamed")
+	if m.Rows[1][0] != "renamed" || m.Rows[2][0] != "renamed" {
+		t.Fatalf("rows after the edit = %v", m.Rows)
+	}
+	if len(m.SelectedRows) != 2 || !m.SelectedRows[1] || !m.SelectedRows[2] {
+		t.Errorf("SelectedRows = %v, want rows 1 and 2", m.SelectedRows)
+	}
+	if fmt.Sprint(m.FilteredRowIndices) != "[1 2]" {
+		t.Errorf("FilteredRowIndices = %v, want [1 2]", m.FilteredRowIndices)
+	}
+
+	// Deleting a column shifts the filter column with it
+	m.CurrentColumn = 0
+	m = typeKeys(m, keyMsg("C"))
+	if m.FilterColumnIndex != 0 || fmt.Sprint(m.FilteredRowIndices) != "[1 2]" {
+		t.Errorf("filter on column %d with rows %v after deleting NAME", m.FilterColumnIndex, m.FilteredRowIndices)
+	}
+}
+
+func TestEditsSurvivePipeUndo(t *testing.T) {
+	m := New([][]string{{"NAME", "STATUS"}, {"web", "Running"}}, 80, 24)
+	m.CurrentColumn = 1
+	m = typeKeys(m, keyMsg("E"))
+	m = editCell(m, "Failed")
+
+	m = pipe(m, "cat")
+	if m.EditChanges().Total() != 0 {
+		t.Errorf("the piped table should start without changes, got %+v", m.EditChanges())
+	}
+
+	// Going back restores the edits, so they can still be reverted
+	m = typeKeys(m, keyMsg("u"))
+	if !m.CellEdited(1, 1) || m.EditChanges() != (EditChanges{Cells: 1}) {
+		t.Fatalf("edits lost after u: rows %v, changes %+v", m.Rows, m.EditChanges())
+	}
+	m = typeKeys(m, keyMsg("U"), keyMsg("y"))
+	if m.Rows[1][1] != "Running" {
+		t.Errorf("U after u should revert the edit, got %v", m.Rows[1])
+	}
+}
+
+func TestEditUnavailable(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web"}}, 80, 24)
+	m.SetRefresher(func() ([][]string, [][]string, error) { return nil, nil, nil }, time.Second)
+	if m = typeKeys(m, keyMsg("E")); m.Editing || !strings.Contains(m.StatusMessage, "watch mode") {
+		t.Errorf("edit mode should be refused in watch mode, status %q", m.StatusMessage)
+	}
+}
@@ -2,97 +2,372 @@ package model
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
-	"tablefy/internal/layout"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/export"
 )
 
-// GetExportData returns the currently visible table data (excluding header)
-// Data is formatted with aligned columns but without borders
-// In NormalView: exports all visible columns
-// In FilterView: exports filtered rows of all columns
-// In ZoomView: exports selected columns
-func (m *Model) GetExportData() string {
+// ExportTable returns the currently visible table data: the header and the rows under it
+// In NormalView: all columns
+// In FilterView: filtered rows of all columns
+// In ZoomView: selected columns
+// ExportColumns, when set, picks the columns by name instead, in the order given
+func (m Model) ExportTable() export.Table {
 	if len(m.Rows) == 0 {
-		return ""
+		return export.Table{}
 	}
 
-	// Determine which rows to use based on filter status
-	var rowIndices []int
-	if len(m.FilteredRowIndices) > 0 {
-		// Use filtered rows
-		rowIndices = m.FilteredRowIndices
+	// Determine which columns to include based on view mode
+	var colIndices []int
+	if len(m.ExportColumns) > 0 {
+		colIndices = m.exportColumnIndices()
 	} else {
-		// Use all rows except header (row 0)
-		for i := 1; i < len(m.Rows); i++ {
-			rowIndices = append(rowIndices, i)
+		for col := 0; col < len(m.Rows[0]); col++ {
+			// In zoom view, only include selected columns (in column order)
+			if m.ViewMode == ZoomView && len(m.SelectedColumns) > 0 && !m.SelectedColumns[col] {
+				continue
+			}
+			colIndices = append(colIndices, col)
 		}
 	}
+	return m.exportTable(colIndices)
+}
 
-	if len(rowIndices) == 0 {
-		return ""
+// templateTable returns the visible rows with every column, so templates can use any header name
+func (m Model) templateTable() export.Table {
+	if len(m.Rows) == 0 {
+		return export.Table{}
 	}
+	colIndices := make([]int, len(m.Rows[0]))
+	for col := range colIndices {
+		colIndices[col] = col
+	}
+	return m.exportTable(colIndices)
+}
 
-	// Determine which columns to include based on view mode
-	var colIndices []int
-	if m.ViewMode == ZoomView && len(m.SelectedColumns) > 0 {
-		// In zoom view, only include selected columns (sorted)
-		for col := 0; col < len(m.Rows[0]); col++ {
-			if m.SelectedColumns[col] {
-				colIndices = append(colIndices, col)
+// tableFor returns the table exported in the format: templates see every column, the others ExportTable
+func (m Model) tableFor(format export.Format) export.Table {
+	if format == export.Template {
+		return m.templateTable()
+	}
+	return m.ExportTable()
+}
+
+// exportTable returns the visible rows reduced to the columns
+func (m Model) exportTable(colIndices []int) export.Table {
+	// Use filtered rows if a filter is active, otherwise all rows except header (row 0)
+	rowIndices := m.DataRowIndices()
+
+	// Exported cells keep the input's escape sequences only when asked to
+	source := m.Rows
+	if m.ExportColors && len(m.StyledRows) == len(m.Rows) {
+		source = m.StyledRows
+	}
+
+	// pick builds a row with only the exported columns
+	pick := func(row []string) []string {
+		cells := make([]string, 0, len(colIndices))
+		for _, colIdx := range colIndices {
+			if colIdx < len(row) {
+				cells = append(cells, row[colIdx])
+			} else {
+				cells = append(cells, "")
 			}
 		}
-	} else {
-		// Include all columns
-		for col := 0; col < len(m.Rows[0]); col++ {
-			colIndices = append(colIndices, col)
+		return cells
+	}
+
+	table := export.Table{Header: pick(m.Rows[0])}
+	for _, rowIdx := range rowIndices {
+		if rowIdx >= 0 && rowIdx < len(source) {
+			table.Rows = append(table.Rows, pick(source[rowIdx]))
 		}
 	}
+	return table
+}
 
-	if len(colIndices) == 0 {
-		return ""
+// Export renders the currently visible table data in the given format
+func (m Model) Export(format export.Format) (string, error) {
+	table := m.tableFor(format)
+	if len(table.Header) == 0 {
+		return "", nil
 	}
+	return m.render(format, table)
+}
 
-	// Build rows to export with only selected columns
-	var rowsToExport [][]string
-	for _, rowIdx := range rowIndices {
-		if rowIdx >= 0 && rowIdx < len(m.Rows) {
-			var rowCols []string
-			for _, colIdx := range colIndices {
-				if colIdx < len(m.Rows[rowIdx]) {
-					rowCols = append(rowCols, m.Rows[rowIdx][colIdx])
-				} else {
-					rowCols = append(rowCols, "")
-				}
-			}
-			rowsToExport = append(rowsToExport, rowCols)
+// render renders the exported table in the format; the template format uses ExportTemplate
+func (m Model) render(format export.Format, table export.Table) (string, error) {
+	opts := m.exportOptions()
+	if format == export.Template {
+		if m.ExportTemplate == "" {
+			return "", fmt.Errorf("no template set, press T to write one")
+		}
+		tmpl, err := export.ParseTemplate(m.ExportTemplate)
+		if err != nil {
+			return "", err
 		}
+		opts.Template = tmpl
 	}
+	return export.Export(format, table, opts)
+}
 
-	// Calculate optimal column widths based on the data
-	widths := layout.CalculateFullColumnWidths(rowsToExport)
+// PendingExport returns what is printed when the session ends: the data exported with o or to "-",
+// or the visible rows through the template T set when the session ended from another tab
+func (m Model) PendingExport() (string, error) {
+	if m.ExportData == "" && m.templateOnQuit {
+		return m.Export(export.Template)
+	}
+	return m.ExportData, nil
+}
 
-	// Format rows with proper alignment (no borders)
-	var lines []string
-	for _, row := range rowsToExport {
-		var paddedCols []string
-		for col := 0; col < len(row); col++ {
-			value := row[col]
+// quit quits, printing the visible rows through the template when T set one
+func (m Model) quit() (tea.Model, tea.Cmd) {
+	if m.templateOnQuit {
+		return m.exportOnQuit()
+	}
+	return m, tea.Quit
+}
 
-			// Get the width for this column
-			width := 0
-			if col < len(widths) {
-				width = widths[col]
-			}
+// exportOnQuit exports the visible table in the current format and quits
+// A template that fails keeps the session going so it can be fixed
+func (m Model) exportOnQuit() (tea.Model, tea.Cmd) {
+	table := m.tableFor(m.ExportFormat)
+	if len(table.Header) == 0 {
+		return m, tea.Quit
+	}
+	data, err := m.render(m.ExportFormat, table)
+	if err != nil {
+		m.StatusMessage = fmt.Sprintf("Export failed: %v", err)
+		return m, nil
+	}
+	m.ExportData = data
+	return m, tea.Quit
+}
+
+// exportDiffOnQuit exports a table of differences (DiffTable or SnapshotDiffTable) in the current format and quits
+func (m Model) exportDiffOnQuit(table export.Table) (tea.Model, tea.Cmd) {
+	if len(table.Rows) == 0 {
+		return m, tea.Quit
+	}
+	data, err := m.render(m.ExportFormat, table)
+	if err != nil {
+		m.StatusMessage = fmt.Sprintf("Export failed: %v", err)
+		return m, nil
+	}
+	m.ExportData = data
+	return m, tea.Quit
+}
+
+// openTemplatePrompt asks for the template exports use, starting from the current one
+func (m *Model) openTemplatePrompt() {
+	m.openPrompt(PromptTemplate, "Template", "e.g. kubectl -n {{.NAMESPACE}} delete pod {{.NAME}} | lower, upper, trim, replace, quote | empty: clear")
+	m.Prompt.Input = m.ExportTemplate
+}
+
+// applyTemplate makes the template the export format and renders the visible rows with it, printed when quitting
+func (m Model) applyTemplate(text string) (tea.Model, tea.Cmd) {
+	if strings.TrimSpace(text) == "" {
+		m.ExportTemplate = ""
+		m.templateOnQuit = false
+		if m.ExportFormat == export.Template {
+			m.ExportFormat = export.Plain
+		}
+		m.StatusMessage = "Template cleared"
+		return m, nil
+	}
+
+	tmpl, err := export.ParseTemplate(text)
+	if err != nil {
+		m.StatusMessage = err.Error()
+		return m, nil
+	}
+	// Try it on the rows now, to report names missing from the header right away
+	if _, err := export.Export(export.Template, m.templateTable(), export.Options{Template: tmpl}); err != nil {
+		m.StatusMessage = err.Error()
+		return m, nil
+	}
+
+	m.ExportTemplate = text
+	m.ExportFormat = export.Template
+	m.templateOnQuit = true
+	m.StatusMessage = "The rows shown when you quit will be printed through the template (O: export them elsewhere)"
+	return m, nil
+}
+
+// exportOptions returns the options the exports use
+func (m Model) exportOptions() export.Options {
+	return export.Options{Header: m.ExportHeader}
+}
 
-			// Pad the value to the column width
-			paddedValue := fmt.Sprintf("%-*s", width, value)
-			paddedCols = append(paddedCols, paddedValue)
+// columnIndex finds a column by header name, falling back to a case-insensitive match
+func (m Model) columnIndex(name string) (int, bool) {
+	if len(m.Rows) == 0 {
+		return -1, false
+	}
+	for i, h := range m.Rows[0] {
+		if h == name {
+			return i, true
+		}
+	}
+	for i, h := range m.Rows[0] {
+		if strings.EqualFold(h, name) {
+			return i, true
 		}
+	}
+	return -1, false
+}
 
-		// Join columns with spaces for alignment
-		lines = append(lines, strings.Join(paddedCols, "  "))
+// exportColumnIndices returns the indices of the ExportColumns found in the header
+func (m Model) exportColumnIndices() []int {
+	var indices []int
+	for _, name := range m.ExportColumns {
+		if idx, ok := m.columnIndex(name); ok {
+			indices = append(indices, idx)
+		}
 	}
+	return indices
+}
 
-	return strings.Join(lines, "\n")
+// MissingExportColumns returns the ExportColumns that are not in the header
+func (m Model) MissingExportColumns() []string {
+	var missing []string
+	for _, name := range m.ExportColumns {
+		if _, ok := m.columnIndex(name); !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// GetExportData returns the currently visible table data as plain text
+// Data is formatted with aligned columns but without borders, with the header only when ExportHeader is set
+func (m *Model) GetExportData() string {
+	// Plain exports can't fail
+	data, _ := m.Export(export.Plain)
+	return data
+}
+
+// ClipboardDestination is the export destination that copies to the clipboard instead of writing a file
+const ClipboardDestination = "clipboard"
+
+// exportRequest is an export waiting to be written
+type exportRequest struct {
+	format export.Format
+	path   string
+	data   string
+	rows   int
+}
+
+// exportDoneMsg reports the outcome of writing an export
+type exportDoneMsg struct {
+	status string
+}
+
+// Clipboard returns the command copying text to the system clipboard, reporting the outcome through done
+type Clipboard func(text string, done tea.ExecCallback) tea.Cmd
+
+// SetClipboard enables exporting to the clipboard with the given copy function
+func (m *Model) SetClipboard(copyText Clipboard) {
+	m.clipboard = copyText
+}
+
+// openExportPrompt asks for the export format and destination, starting from the current format
+func (m *Model) openExportPrompt() {
+	m.openPrompt(PromptExport, exportPromptLabel(m.ExportHeader), fmt.Sprintf("FORMAT [FILE | - | %s] (%s; default - prints when quitting) | Tab: Toggle header", ClipboardDestination, strings.Join(export.Names(), ", ")))
+	m.Prompt.Input = string(m.ExportFormat) + " "
+}
+
+// exportPromptLabel shows whether plain exports include the header
+func exportPromptLabel(header bool) string {
+	if header {
+		return "Export (with header)"
+	}
+	return "Export (no header)"
+}
+
+// parseExportPrompt parses "FORMAT [DESTINATION]"; the destination is the rest of the line, so paths may contain spaces
+func (m Model) parseExportPrompt(input string) (export.Format, string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return m.ExportFormat, "-", nil
+	}
+
+	name, destination, _ := strings.Cut(input, " ")
+	format, err := export.ParseFormat(name)
+	if err != nil {
+		return "", "", err
+	}
+
+	destination = strings.TrimSpace(destination)
+	switch {
+	case destination == "":
+		destination = "-"
+	case strings.HasPrefix(destination, "~/"):
+		if home, err := os.UserHomeDir(); err == nil {
+			destination = filepath.Join(home, destination[2:])
+		}
+	}
+	return format, destination, nil
+}
+
+// startExport exports the visible table as answered in the export prompt; the session goes on afterwards
+func (m Model) startExport(input string) (tea.Model, tea.Cmd) {
+	format, destination, err := m.parseExportPrompt(input)
+	if err != nil {
+		m.StatusMessage = fmt.Sprintf("Export failed: %v", err)
+		return m, nil
+	}
+
+	table := m.tableFor(format)
+	if len(table.Header) == 0 {
+		m.StatusMessage = "Nothing to export"
+		return m, nil
+	}
+	data, err := m.render(format, table)
+	if err != nil {
+		m.StatusMessage = fmt.Sprintf("Export failed: %v", err)
+		return m, nil
+	}
+	m.ExportFormat = format
+	m.templateOnQuit = false
+	request := exportRequest{format: format, path: destination, data: data, rows: len(table.Rows)}
+
+	switch destination {
+	case "-":
+		m.ExportData = request.data
+		m.StatusMessage = fmt.Sprintf("%d rows (%s) will be printed when you quit", request.rows, format)
+		return m, nil
+	case ClipboardDestination:
+		if m.clipboard == nil {
+			m.StatusMessage = "Export failed: no clipboard available"
+			return m, nil
+		}
+		return m, m.clipboard(request.data, func(err error) tea.Msg {
+			if err != nil {
+				return exportDoneMsg{status: fmt.Sprintf("Export failed: %v", err)}
+			}
+			return exportDoneMsg{status: fmt.Sprintf("Copied %d rows (%s) to the clipboard", request.rows, format)}
+		})
+	}
+
+	if _, err := os.Stat(destination); err == nil {
+		m.pendingExport = &request
+		m.openConfirm(PromptOverwrite, fmt.Sprintf("%s exists, overwrite it?", destination))
+		return m, nil
+	}
+	return m, writeExportFile(request)
+}
+
+// writeExportFile writes an export to its file in the background
+func writeExportFile(request exportRequest) tea.Cmd {
+	return func() tea.Msg {
+		if err := os.WriteFile(request.path, []byte(request.data+"\n"), 0o644); err != nil {
+			return exportDoneMsg{status: fmt.Sprintf("Export failed: %v", err)}
+		}
+		return exportDoneMsg{status: fmt.Sprintf("Exported %d rows (%s) to %s", request.rows, request.format, request.path)}
+	}
 }
@@ -1,8 +1,14 @@
 package model
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/export"
 )
 
 // TestGetExportData tests exporting all columns with proper alignment
@@ -181,3 +187,247 @@ func TestGetExportDataZoomViewSingleColumn(t *testing.T) {
 		t.Errorf("Expected '30' in second line, got '%s'", lines[1])
 	}
 }
+
+// TestGetExportDataAlignmentUnicode tests that wide characters are padded by display width
+func TestGetExportDataAlignmentUnicode(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "STATUS"},
+		{"日本語", "ok"},
+		{"abc", "ok"},
+	}
+
+	m := New(rows, 80, 24)
+	output := m.GetExportData()
+	lines := strings.Split(output, "\n")
+
+	// "日本語" is 6 cells wide, so "abc" needs 3 extra spaces to line up
+	if lines[0] != "日本語  ok" {
+		t.Errorf("Line 0 = %q, want %q", lines[0], "日本語  ok")
+	}
+	if lines[1] != "abc     ok" {
+		t.Errorf("Line 1 = %q, want %q", lines[1], "abc     ok")
+	}
+}
+
+// TestGetExportDataColors tests that input colors are stripped by default and kept on request
+func TestGetExportDataColors(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "STATUS"},
+		{"web", "Running"},
+	}
+	styled := [][]string{
+		{"NAME", "STATUS"},
+		{"web", "\x1b[32mRunning\x1b[0m"},
+	}
+
+	m := New(rows, 80, 24)
+	m.StyledRows = styled
+
+	if output := m.GetExportData(); strings.Contains(output, "\x1b") {
+		t.Errorf("Expected stripped export by default, got %q", output)
+	}
+
+	m.ExportColors = true
+	output := m.GetExportData()
+	if !strings.Contains(output, "\x1b[32mRunning") {
+		t.Errorf("Expected preserved colors in export, got %q", output)
+	}
+}
+
+func TestExportFormats(t *testing.T) {
+	m := New([][]string{{"NAME", "STATUS"}, {"web", "Running"}, {"db", "Pending"}}, 80, 24)
+	m.FilterColumnIndex = 1
+	m.FilteredRowIndices = ApplyFuzzyFilter(m.Rows, 1, "pend")
+
+	if got, _ := m.Export(export.CSV); got != "NAME,STATUS\ndb,Pending" {
+		t.Errorf("CSV export = %q", got)
+	}
+
+	m.ExportFormat = export.NDJSON
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if got, want := next.(Model).ExportData, `{"NAME":"db","STATUS":"Pending"}`; got != want {
+		t.Errorf("o exported %q, want %q", got, want)
+	}
+}
+
+func TestExportPrompt(t *testing.T) {
+	m := New([][]string{{"NAME", "STATUS"}, {"web", "Running"}}, 80, 24)
+	m.SelectedColumns[0] = true
+	m.ViewMode = ZoomView
+
+	m = typeKeys(m, keyMsg("O"))
+	if m.Prompt == nil || m.Prompt.Input != "plain " {
+		t.Fatalf("O should open the export prompt with the current format, got %+v", m.Prompt)
+	}
+
+	// Replace the format and export to stdout, printed when quitting
+	for range "plain " {
+		m = typeKeys(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	m = typeKeys(m, typeText("md")...)
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Fatal("exporting to stdout should not quit")
+	}
+	m = next.(Model)
+	if got, want := m.ExportData, "| NAME |\n| --- |\n| web |"; got != want {
+		t.Errorf("exported %q, want %q", got, want)
+	}
+	if m.ExportFormat != export.Markdown || !strings.Contains(m.StatusMessage, "printed when you quit") {
+		t.Errorf("format %q, status %q", m.ExportFormat, m.StatusMessage)
+	}
+
+	// An unknown format keeps the session going
+	m = exportWith(m, "xml")
+	if !strings.Contains(m.StatusMessage, "unknown export format") {
+		t.Errorf("status %q should report the unknown format", m.StatusMessage)
+	}
+}
+
+// exportWith answers the export prompt
+func exportWith(m Model, answer string) Model {
+	m = typeKeys(m, keyMsg("O"))
+	m.Prompt.Input = answer
+	return typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestExportToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pods list.csv")
+	m := New([][]string{{"NAME", "STATUS"}, {"web", "Running"}}, 80, 24)
+
+	m = exportWith(m, "csv "+path)
+	if data, err := os.ReadFile(path); err != nil || string(data) != "NAME,STATUS\nweb,Running\n" {
+		t.Fatalf("file holds %q (%v)", data, err)
+	}
+	if !strings.Contains(m.StatusMessage, "Exported 1 rows (csv)") {
+		t.Errorf("status %q", m.StatusMessage)
+	}
+
+	// An existing file is only replaced after confirming
+	m = exportWith(m, "tsv "+path)
+	if m.Prompt == nil || !m.Prompt.Confirm {
+		t.Fatal("expected an overwrite confirmation")
+	}
+	m = typeKeys(m, keyMsg("n"))
+	if data, _ := os.ReadFile(path); !strings.HasPrefix(string(data), "NAME,") {
+		t.Errorf("file replaced without confirmation: %q", data)
+	}
+
+	m = exportWith(m, "tsv "+path)
+	m = typeKeys(m, keyMsg("y"))
+	if data, _ := os.ReadFile(path); string(data) != "NAME\tSTATUS\nweb\tRunning\n" {
+		t.Errorf("file holds %q after confirming", data)
+	}
+}
+
+func TestExportToClipboard(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web"}}, 80, 24)
+	m = exportWith(m, "json clipboard")
+	if !strings.Contains(m.StatusMessage, "no clipboard") {
+		t.Errorf("status %q should report the missing clipboard", m.StatusMessage)
+	}
+
+	var copied string
+	m.SetClipboard(fakeClipboard(func(text string) error {
+		copied = text
+		return nil
+	}))
+	m = exportWith(m, "ndjson clipboard")
+	if copied != `{"NAME":"web"}` || !strings.Contains(m.StatusMessage, "Copied 1 rows") {
+		t.Errorf("copied %q, status %q", copied, m.StatusMessage)
+	}
+}
+
+func TestExportHeaderAndColumns(t *testing.T) {
+	m := New([][]string{{"NAMESPACE", "NAME", "STATUS"}, {"prod", "web", "Running"}}, 80, 24)
+	m.ExportHeader = true
+	m.ExportColumns = []string{"name", "NAMESPACE", "AGE"}
+
+	if got, want := m.GetExportData(), "NAME  NAMESPACE\nweb   prod     "; got != want {
+		t.Errorf("export = %q, want %q", got, want)
+	}
+	if missing := m.MissingExportColumns(); len(missing) != 1 || missing[0] != "AGE" {
+		t.Errorf("MissingExportColumns = %v, want [AGE]", missing)
+	}
+
+	// Named columns win over the zoom selection
+	m.SelectedColumns[2] = true
+	m.ViewMode = ZoomView
+	if got, _ := m.Export(export.CSV); got != "NAME,NAMESPACE\nweb,prod" {
+		t.Errorf("CSV export in zoom = %q", got)
+	}
+}
+
+func TestExportPromptHeaderToggle(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web"}}, 80, 24)
+	m = typeKeys(m, keyMsg("O"), tea.KeyMsg{Type: tea.KeyTab})
+	if !m.ExportHeader || !strings.Contains(m.Prompt.Label, "with header") {
+		t.Fatalf("Tab should turn the header on, label %q", m.Prompt.Label)
+	}
+
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.ExportData != "NAME\nweb " {
+		t.Errorf("export = %q, want the header included", m.ExportData)
+	}
+}
+
+func TestTemplateExport(t *testing.T) {
+	m := New([][]string{{"NAMESPACE", "NAME"}, {"prod", "web"}, {"dev", "db"}}, 80, 24)
+	m.FilterColumnIndex = 0
+	m.FilteredRowIndices = ApplyFuzzyFilter(m.Rows, 0, "prod")
+
+	m = typeKeys(m, keyMsg("T"))
+	m = typeKeys(m, typeText("kubectl -n {{.NAMESPACE}} delete pod {{.NAME}}")...)
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.ExportFormat != export.Template || m.ExportData != "" {
+		t.Fatalf("format %q, export %q; the template should only run when quitting", m.ExportFormat, m.ExportData)
+	}
+
+	// q renders the rows visible when quitting, not those of the time T was pressed
+	filtered := m
+	filtered.FilteredRowIndices = ApplyFuzzyFilter(m.Rows, 0, "dev")
+	next, _ := filtered.Update(keyMsg("q"))
+	if got := next.(Model).ExportData; got != "kubectl -n dev delete pod db" {
+		t.Errorf("q printed %q", got)
+	}
+
+	// o renders the visible rows with the template
+	m.ClearFilter()
+	next, _ = m.Update(keyMsg("o"))
+	if got := next.(Model).ExportData; got != "kubectl -n prod delete pod web\nkubectl -n dev delete pod db" {
+		t.Errorf("o exported %q", got)
+	}
+
+	// A typo is reported and nothing changes
+	m = typeKeys(m, keyMsg("T"))
+	m.Prompt.Input = "{{.NAMESPCE}}"
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.StatusMessage, "NAMESPCE") || m.ExportTemplate != "kubectl -n {{.NAMESPACE}} delete pod {{.NAME}}" {
+		t.Errorf("status %q, template %q", m.StatusMessage, m.ExportTemplate)
+	}
+
+	// An empty template goes back to plain exports
+	m = typeKeys(m, keyMsg("T"))
+	m.Prompt.Input = ""
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.ExportFormat != export.Plain || m.ExportTemplate != "" {
+		t.Errorf("format %q, template %q after clearing", m.ExportFormat, m.ExportTemplate)
+	}
+}
+
+func TestTemplateSeesEveryColumnInZoom(t *testing.T) {
+	m := New([][]string{{"NAMESPACE", "NAME", "STATUS"}, {"prod", "web", "Running"}}, 80, 24)
+	m.SelectedColumns[1] = true
+	m.ViewMode = ZoomView
+
+	m = typeKeys(m, keyMsg("T"))
+	m.Prompt.Input = "{{.NAMESPACE}}/{{.NAME}}"
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.ExportTemplate == "" {
+		t.Fatalf("template not set in zoom view (status %q)", m.StatusMessage)
+	}
+	if got, err := m.Export(export.Template); err != nil || got != "prod/web" {
+		t.Errorf("Export(template) = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// numericCellPattern matches a number optionally followed by a unit suffix (e.g. "12.5", "30%", "120ms", "3d")
+var numericCellPattern = regexp.MustCompile(`^([-+]?(?:\d+\.?\d*|\.\d+))\s*([A-Za-zµ%]*)$`)
+
+// NumericRange holds the minimum and maximum numeric values found in a column
+type NumericRange struct {
+	Min   float64
+	Max   float64
+	Valid bool // True when the column is considered numeric
+}
+
+// Position returns where value sits between Min and Max, scaled to [0, 1]
+// A column where every value is identical places all values at 0
+func (r NumericRange) Position(value float64) float64 {
+	if !r.Valid || r.Max <= r.Min {
+		return 0
+	}
+	pos := (value - r.Min) / (r.Max - r.Min)
+	if pos < 0 {
+		return 0
+	}
+	if pos > 1 {
+		return 1
+	}
+	return pos
+}
+
+// NumericValue parses a cell as a number, ignoring a trailing unit suffix
+// Returns false for cells that are not numeric (names, versions, IPs, ratios like "1/1")
+func NumericValue(cell string) (float64, bool) {
+	match := numericCellPattern.FindStringSubmatch(strings.TrimSpace(cell))
+	if match == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// ColumnNumericRanges calculates the numeric range of every column over the data rows (header excluded)
+// A column is numeric when at least half of its non-empty cells parse as numbers
+func ColumnNumericRanges(rows [][]string) []NumericRange {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	numCols := len(rows[0])
+	ranges := make([]NumericRange, numCols)
+
+	for col := 0; col < numCols; col++ {
+		numeric := 0
+		nonEmpty := 0
+		for i := 1; i < len(rows); i++ {
+			if col >= len(rows[i]) || strings.TrimSpace(rows[i][col]) == "" {
+				continue
+			}
+			nonEmpty++
+
+			value, ok := NumericValue(rows[i][col])
+			if !ok {
+				continue
+			}
+			if numeric == 0 || value < ranges[col].Min {
+				ranges[col].Min = value
+			}
+			if numeric == 0 || value > ranges[col].Max {
+				ranges[col].Max = value
+			}
+			numeric++
+		}
+		ranges[col].Valid = numeric > 0 && numeric*2 >= nonEmpty
+	}
+
+	return ranges
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestNumericValue(t *testing.T) {
+	tests := []struct {
+		cell     string
+		expected float64
+		ok       bool
+	}{
+		{"12.5", 12.5, true},
+		{"0", 0, true},
+		{"-3", -3, true},
+		{"30%", 30, true},
+		{"120ms", 120, true},
+		{"45d", 45, true},
+		{" 7 ", 7, true},
+		{"1/1", 0, false},
+		{"10.0.0.1", 0, false},
+		{"v1.9.1", 0, false},
+		{"running", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		value, ok := NumericValue(tt.cell)
+		if ok != tt.ok || value != tt.expected {
+			t.Errorf("NumericValue(%q) = (%v, %v), want (%v, %v)", tt.cell, value, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestColumnNumericRanges(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "%CPU", "LATENCY", "READY"},
+		{"web-1", "12.5", "120ms", "1/1"},
+		{"web-2", "0.3", "-", "1/1"},
+		{"db", "87.0", "15ms", "0/1"},
+	}
+
+	ranges := ColumnNumericRanges(rows)
+
+	if ranges[0].Valid {
+		t.Errorf("NAME column should not be numeric")
+	}
+	if !ranges[1].Valid || ranges[1].Min != 0.3 || ranges[1].Max != 87.0 {
+		t.Errorf("%%CPU range = %+v, want min 0.3 max 87", ranges[1])
+	}
+	// Placeholder values like "-" are tolerated as long as most cells are numeric
+	if !ranges[2].Valid || ranges[2].Min != 15 || ranges[2].Max != 120 {
+		t.Errorf("LATENCY range = %+v, want min 15 max 120", ranges[2])
+	}
+	if ranges[3].Valid {
+		t.Errorf("READY column should not be numeric")
+	}
+}
+
+func TestNumericRangePosition(t *testing.T) {
+	r := NumericRange{Min: 10, Max: 20, Valid: true}
+
+	if pos := r.Position(10); pos != 0 {
+		t.Errorf("Position(min) = %v, want 0", pos)
+	}
+	if pos := r.Position(15); pos != 0.5 {
+		t.Errorf("Position(mid) = %v, want 0.5", pos)
+	}
+	if pos := r.Position(20); pos != 1 {
+		t.Errorf("Position(max) = %v, want 1", pos)
+	}
+
+	// Identical values must not divide by zero
+	flat := NumericRange{Min: 5, Max: 5, Valid: true}
+	if pos := flat.Position(5); pos != 0 {
+		t.Errorf("Position on flat range = %v, want 0", pos)
+	}
+}
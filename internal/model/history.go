@@ -0,0 +1,129 @@
+package model
+
+import (
+	"time"
+
+	"tablefy/internal/diff"
+	"tablefy/internal/export"
+)
+
+// DefaultHistorySize is how many snapshots watch mode keeps by default
+const DefaultHistorySize = 20
+
+// Snapshot is one parsed refresh of the watched command
+type Snapshot struct {
+	Rows       [][]string
+	StyledRows [][]string
+	Time       time.Time
+}
+
+// recordSnapshot appends a snapshot to the history, dropping the oldest ones beyond HistorySize
+func (m *Model) recordSnapshot(rows, styled [][]string, at time.Time) {
+	if m.HistorySize <= 0 {
+		return
+	}
+
+	m.History = append(m.History, Snapshot{Rows: rows, StyledRows: styled, Time: at})
+	if m.Browsing() {
+		// Keep the browsed snapshot on screen: it is now one step further back
+		m.HistoryBack++
+	}
+	if len(m.History) > m.HistorySize {
+		m.History = append([]Snapshot(nil), m.History[len(m.History)-m.HistorySize:]...)
+	}
+
+	// The browsed snapshot fell out of the history: show the oldest one kept
+	if m.HistoryBack >= len(m.History) {
+		m.HistoryBack = len(m.History) - 1
+		snapshot := m.History[0]
+		m.showSnapshot(snapshot.Rows, snapshot.StyledRows)
+	}
+}
+
+// liveRows returns the rows of the latest snapshot
+func (m Model) liveRows() [][]string {
+	if len(m.History) > 0 {
+		return m.History[len(m.History)-1].Rows
+	}
+	return m.Rows
+}
+
+// Browsing reports whether a past snapshot is shown instead of the live one
+func (m Model) Browsing() bool {
+	return m.HistoryBack > 0
+}
+
+// ShownSnapshot returns the position (1 = oldest) of the snapshot on screen and the history length
+func (m Model) ShownSnapshot() (position, total int) {
+	return len(m.History) - m.HistoryBack, len(m.History)
+}
+
+// ShownSnapshotTime returns when the snapshot on screen was taken
+func (m Model) ShownSnapshotTime() time.Time {
+	if len(m.History) == 0 {
+		return m.LastRefresh
+	}
+	return m.History[len(m.History)-1-m.HistoryBack].Time
+}
+
+// stepHistory moves through the history (negative delta is older) and shows the snapshot reached
+// Stepping past the newest snapshot returns to the live view
+func (m *Model) stepHistory(delta int) {
+	if len(m.History) < 2 {
+		return
+	}
+
+	back := min(max(m.HistoryBack-delta, 0), len(m.History)-1)
+	if back == m.HistoryBack {
+		return
+	}
+	m.HistoryBack = back
+
+	snapshot := m.History[len(m.History)-1-back]
+	m.showSnapshot(snapshot.Rows, snapshot.StyledRows)
+}
+
+// markDiffBase marks the snapshot on screen as the base for diff exports, or unmarks it
+func (m *Model) markDiffBase() {
+	if len(m.History) == 0 {
+		return
+	}
+	shown := m.ShownSnapshotTime()
+	if m.DiffBase.Equal(shown) {
+		m.DiffBase = time.Time{}
+		return
+	}
+	m.DiffBase = shown
+}
+
+// snapshotAt returns the snapshot taken at the given time
+func (m Model) snapshotAt(at time.Time) (Snapshot, bool) {
+	for _, snapshot := range m.History {
+		if snapshot.Time.Equal(at) {
+			return snapshot, true
+		}
+	}
+	return Snapshot{}, false
+}
+
+// SnapshotDiffTable returns the differences between the marked base snapshot and the one on screen,
+// laid out for the exporters like DiffTable
+// Without a base (or when the base is on screen) the snapshot before the one on screen is used
+func (m Model) SnapshotDiffTable() export.Table {
+	if len(m.History) < 2 {
+		return export.Table{}
+	}
+
+	shownIdx := len(m.History) - 1 - m.HistoryBack
+	target := m.History[shownIdx]
+	base, ok := m.snapshotAt(m.DiffBase)
+	if !ok || base.Time.Equal(target.Time) {
+		if shownIdx == 0 {
+			return export.Table{}
+		}
+		base = m.History[shownIdx-1]
+	}
+
+	rows := diff.Compare(base.Rows, target.Rows, m.RowKey).Table()
+	return export.Table{Header: rows[0], Rows: rows[1:]}
+}
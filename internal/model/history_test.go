@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"tablefy/internal/export"
+)
+
+// refreshWith delivers a successful refresh taken at the given time
+func refreshWith(m Model, rows [][]string, at time.Time) Model {
+	next, _ := m.handleRefresh(RefreshMsg{Rows: rows, Time: at})
+	return next.(Model)
+}
+
+func TestHistoryStepping(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := New([][]string{{"NAME", "STATUS"}, {"web", "Running"}}, 80, 24)
+	m.SetRefresher(func() ([][]string, [][]string, error) { return nil, nil, nil }, time.Second)
+	m.History[0].Time = start
+	m.WatchPaused = true
+
+	m = refreshWith(m, [][]string{{"NAME", "STATUS"}, {"web", "Pending"}}, start.Add(time.Second))
+	m = refreshWith(m, [][]string{{"NAME", "STATUS"}, {"web", "Failed"}}, start.Add(2*time.Second))
+
+	m.stepHistory(-1)
+	m.stepHistory(-1)
+	if m.Rows[1][1] != "Running" || !m.Browsing() {
+		t.Fatalf("expected the first snapshot after stepping back twice, got %v", m.Rows)
+	}
+	if pos, total := m.ShownSnapshot(); pos != 1 || total != 3 {
+		t.Errorf("ShownSnapshot() = %d/%d, want 1/3", pos, total)
+	}
+	if !m.ShownSnapshotTime().Equal(start) {
+		t.Errorf("ShownSnapshotTime() = %v, want %v", m.ShownSnapshotTime(), start)
+	}
+
+	// A refresh while browsing is recorded but keeps the past snapshot on screen
+	m = refreshWith(m, [][]string{{"NAME", "STATUS"}, {"web", "Running"}, {"db", "Running"}}, start.Add(3*time.Second))
+	if m.Rows[1][1] != "Running" || len(m.Rows) != 2 || len(m.History) != 4 {
+		t.Errorf("refresh while browsing replaced the shown snapshot: %v", m.Rows)
+	}
+
+	// Stepping past the newest snapshot returns to the live view
+	for range 5 {
+		m.stepHistory(1)
+	}
+	if m.Browsing() || len(m.Rows) != 3 {
+		t.Errorf("expected the live snapshot, got %v", m.Rows)
+	}
+}
+
+func TestHistoryIsBounded(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := New([][]string{{"N"}, {"0"}}, 80, 24)
+	m.HistorySize = 3
+	m.SetRefresher(func() ([][]string, [][]string, error) { return nil, nil, nil }, time.Second)
+	m.WatchPaused = true
+
+	m = refreshWith(m, [][]string{{"N"}, {"1"}}, start.Add(time.Second))
+	m.stepHistory(-1) // Browse snapshot "0"
+	for i := 2; i <= 4; i++ {
+		m = refreshWith(m, [][]string{{"N"}, {string(rune('0' + i))}}, start.Add(time.Duration(i)*time.Second))
+	}
+
+	if len(m.History) != 3 {
+		t.Fatalf("len(History) = %d, want 3", len(m.History))
+	}
+	// The browsed snapshot fell out of the history, so the oldest one kept is shown
+	if m.Rows[1][0] != "2" {
+		t.Errorf("expected oldest kept snapshot 2 on screen, got %v", m.Rows)
+	}
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := New([][]string{{"NAME", "STATUS"}, {"web", "Running"}, {"db", "Running"}}, 80, 24)
+	m.SetRefresher(func() ([][]string, [][]string, error) { return nil, nil, nil }, time.Second)
+	m.History[0].Time = start
+	m.WatchPaused = true
+
+	m = refreshWith(m, [][]string{{"NAME", "STATUS"}, {"web", "Pending"}, {"db", "Running"}}, start.Add(time.Second))
+	m = refreshWith(m, [][]string{{"NAME", "STATUS"}, {"web", "Pending"}}, start.Add(2*time.Second))
+
+	// Without a base the live snapshot is compared with the one before it
+	table := m.SnapshotDiffTable()
+	if fmt.Sprint(table.Rows) != "[[removed db Running]]" {
+		t.Errorf("unexpected diff against the previous snapshot: %v", table.Rows)
+	}
+
+	// With the first snapshot marked as base, both changes show up
+	m.stepHistory(-2)
+	m.markDiffBase()
+	m.stepHistory(2)
+	table = m.SnapshotDiffTable()
+	if fmt.Sprint(table.Rows) != "[[changed web Pending Running] [removed db Running ]]" {
+		t.Errorf("unexpected diff against the marked base: %v", table.Rows)
+	}
+
+	// D exports it in the export format
+	m.ExportFormat = export.CSV
+	next, _ := m.Update(keyMsg("D"))
+	if got := next.(Model).ExportData; !strings.HasPrefix(got, "CHANGE,NAME,STATUS,old.STATUS\n") {
+		t.Errorf("D exported %q", got)
+	}
+}
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/join"
+)
+
+// TableLoader reads a whole table from a file ("-" for stdin), for commands that bring in another input
+type TableLoader func(path string) ([][]string, error)
+
+// joinSpec is a parsed join prompt
+type joinSpec struct {
+	path              string
+	leftKey, rightKey string
+	kind              join.Kind
+}
+
+// joinLoadedMsg carries the table to join once it is read
+type joinLoadedMsg struct {
+	spec joinSpec
+	rows [][]string
+	err  error
+}
+
+// SetTableLoader enables commands that read another input, such as the interactive join
+func (m *Model) SetTableLoader(loader TableLoader) {
+	m.loadTable = loader
+}
+
+// parseJoinPrompt parses "[inner|left|full] [LEFT_KEY=RIGHT_KEY | =RIGHT_KEY] FILE"
+// The file is the rest of the line, so paths may contain spaces. The left key defaults to the focused column,
+// the right key to a column of the same name, the type to left
+func (m Model) parseJoinPrompt(input string) (joinSpec, error) {
+	spec := joinSpec{kind: join.Left}
+	if len(m.Rows) > 0 && m.CurrentColumn < len(m.Rows[0]) {
+		spec.leftKey = m.Rows[0][m.CurrentColumn]
+		spec.rightKey = spec.leftKey
+	}
+
+	rest := strings.TrimSpace(input)
+	for {
+		// The last word is always part of the file, even when it looks like an option
+		word, after, found := strings.Cut(rest, " ")
+		if !found {
+			break
+		}
+		if kind, err := join.ParseKind(word); err == nil {
+			spec.kind = kind
+		} else if left, right, isKey := strings.Cut(word, "="); isKey {
+			if left == "" {
+				left = spec.leftKey
+			}
+			left, right, err := join.ParseOn(left + "=" + right)
+			if err != nil {
+				return joinSpec{}, err
+			}
+			spec.leftKey, spec.rightKey = left, right
+		} else {
+			break
+		}
+		rest = strings.TrimSpace(after)
+	}
+
+	if rest == "" {
+		return joinSpec{}, fmt.Errorf("no file given")
+	}
+	spec.path = rest
+	return spec, nil
+}
+
+// startJoin reads the file named in the join prompt in the background
+func (m Model) startJoin(input string) (tea.Model, tea.Cmd) {
+	switch {
+	case m.loadTable == nil:
+		m.StatusMessage = "Join is not available"
+		return m, nil
+	case m.Watching():
+		m.StatusMessage = "Join is not available in watch mode: refreshes would replace the result"
+		return m, nil
+	case m.Diffing():
+		m.StatusMessage = "Join is not available while comparing tables"
+		return m, nil
+	case m.StreamOpen:
+		m.StatusMessage = "Wait for the input to finish loading before joining"
+		return m, nil
+	}
+
+	spec, err := m.parseJoinPrompt(input)
+	if err != nil {
+		m.StatusMessage = fmt.Sprintf("Join failed: %v", err)
+		return m, nil
+	}
+
+	m.StatusMessage = fmt.Sprintf("Loading %s...", spec.path)
+	load := m.loadTable
+	return m, func() tea.Msg {
+		rows, err := load(spec.path)
+		return joinLoadedMsg{spec: spec, rows: rows, err: err}
+	}
+}
+
+// handleJoinLoaded joins the loaded table into the rows; the result is an ordinary table
+func (m Model) handleJoinLoaded(msg joinLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.StatusMessage = fmt.Sprintf("Join failed: %v", msg.err)
+		return m, nil
+	}
+
+	rows, err := join.Join(m.Rows, msg.rows, join.Options{
+		LeftKey:     msg.spec.leftKey,
+		RightKey:    msg.spec.rightKey,
+		Kind:        msg.spec.kind,
+		RightPrefix: join.PrefixFor(msg.spec.path),
+	})
+	if err != nil {
+		m.StatusMessage = fmt.Sprintf("Join failed: %v", err)
+		return m, nil
+	}
+
+	m.Ghosts = nil
+	m.forgetEdits()
+	m.ReplaceRows(rows, nil)
+	m.StatusMessage = fmt.Sprintf("Joined %s on %s=%s (%s join): %d rows", msg.spec.path, msg.spec.leftKey, msg.spec.rightKey, msg.spec.kind, len(rows)-1)
+	return m, nil
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeKeys sends each key to the model, running any command it returns
+func typeKeys(m Model, keys ...tea.KeyMsg) Model {
+	for _, key := range keys {
+		next, cmd := m.Update(key)
+		m = next.(Model)
+		if cmd != nil {
+			next, _ = m.Update(cmd())
+			m = next.(Model)
+		}
+	}
+	return m
+}
+
+// typeText builds the key messages for typing text, spaces included
+func typeText(text string) []tea.KeyMsg {
+	var keys []tea.KeyMsg
+	for _, r := range text {
+		if r == ' ' {
+			keys = append(keys, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+			continue
+		}
+		keys = append(keys, keyMsg(string(r)))
+	}
+	return keys
+}
+
+func TestJoinPrompt(t *testing.T) {
+	pods := [][]string{{"POD", "NODE"}, {"web", "n1"}, {"db", "n2"}}
+	nodes := [][]string{{"NAME", "ZONE"}, {"n1", "a"}}
+
+	m := New(pods, 80, 24)
+	m.CurrentColumn = 1
+	var loaded string
+	m.SetTableLoader(func(path string) ([][]string, error) {
+		loaded = path
+		return nodes, nil
+	})
+
+	m = typeKeys(m, keyMsg("J"))
+	if m.Prompt == nil || !m.CapturesKeys() {
+		t.Fatal("J should open the join prompt")
+	}
+
+	m = typeKeys(m, typeText("=NAME /tmp/nodes.txtx")...)
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.Prompt != nil {
+		t.Error("prompt should close after Enter")
+	}
+	if loaded != "/tmp/nodes.txt" {
+		t.Errorf("loaded %q, want /tmp/nodes.txt", loaded)
+	}
+	want := [][]string{{"POD", "NODE", "ZONE"}, {"web", "n1", "a"}, {"db", "n2", ""}}
+	if fmt.Sprint(m.Rows) != fmt.Sprint(want) {
+		t.Errorf("joined rows = %v, want %v", m.Rows, want)
+	}
+	if !strings.Contains(m.StatusMessage, "left join") {
+		t.Errorf("status %q should describe the join", m.StatusMessage)
+	}
+
+	// The status message goes away with the next key
+	m = typeKeys(m, keyMsg("l"))
+	if m.StatusMessage != "" {
+		t.Errorf("status message kept after a key: %q", m.StatusMessage)
+	}
+}
+
+func TestJoinPromptCancel(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web"}}, 80, 24)
+	m.SetTableLoader(func(string) ([][]string, error) {
+		t.Fatal("cancelled prompt should not load anything")
+		return nil, nil
+	})
+
+	m = typeKeys(m, keyMsg("J"), keyMsg("q"), tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Prompt != nil {
+		t.Error("Esc should close the prompt")
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web"}}, 80, 24)
+	m.SetTableLoader(func(string) ([][]string, error) { return nil, errors.New("no such file") })
+
+	m = typeKeys(m, keyMsg("J"))
+	m = typeKeys(m, typeText("missing.txt")...)
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.StatusMessage, "no such file") {
+		t.Errorf("status %q should report the load error", m.StatusMessage)
+	}
+	if len(m.Rows) != 2 {
+		t.Errorf("rows changed after a failed join: %v", m.Rows)
+	}
+
+	m.StreamOpen = true
+	m = typeKeys(m, keyMsg("J"))
+	m = typeKeys(m, typeText("other.txt")...)
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.StatusMessage, "finish loading") {
+		t.Errorf("status %q should refuse joining while loading", m.StatusMessage)
+	}
+}
+
+func TestParseJoinPrompt(t *testing.T) {
+	m := New([][]string{{"POD", "NODE"}, {"web", "n1"}}, 80, 24)
+	m.CurrentColumn = 1
+
+	tests := []struct {
+		input, want string
+	}{
+		{"nodes.txt", "{nodes.txt NODE NODE left}"},
+		{"=NAME nodes.txt", "{nodes.txt NODE NAME left}"},
+		{"inner POD=NAME nodes.txt", "{nodes.txt POD NAME inner}"},
+		{"full nodes.txt", "{nodes.txt NODE NODE full}"},
+		{"full my nodes.txt", "{my nodes.txt NODE NODE full}"},
+		{"  left", "{left NODE NODE left}"},
+	}
+	for _, tt := range tests {
+		spec, err := m.parseJoinPrompt(tt.input)
+		if err != nil {
+			t.Errorf("parseJoinPrompt(%q): %v", tt.input, err)
+			continue
+		}
+		if got := fmt.Sprintf("{%s %s %s %s}", spec.path, spec.leftKey, spec.rightKey, spec.kind); got != tt.want {
+			t.Errorf("parseJoinPrompt(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+
+	if _, err := m.parseJoinPrompt("  "); err == nil {
+		t.Error("expected an error without a file")
+	}
+}
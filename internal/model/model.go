@@ -1,25 +1,108 @@
 package model
 
 import (
+	"context"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/action"
+	"tablefy/internal/diff"
+	"tablefy/internal/export"
+	"tablefy/internal/layout"
+	"tablefy/internal/theme"
 )
 
 // Model represents the application state
 type Model struct {
 	Rows               [][]string
+	StyledRows         [][]string // Rows with the input's ANSI escapes preserved (nil when the input had none)
+	KeepColors         bool       // Render cells with their original input colors
+	ExportColors       bool       // Keep the input's ANSI escapes in exported data
 	CurrentColumn      int
 	SelectedColumns    map[int]bool
+	CursorRow          int             // Position of the row cursor among the shown data rows, see CursorPosition
+	SelectedRows       map[int]bool    // Rows selected for actions, by index in Rows
+	Actions            []action.Action // Commands to run on rows, from a key or the action menu
+	PreviewCommand     string          // Command previewing the row under the cursor, with {HEADER} placeholders
+	PreviewVisible     bool            // The preview pane is shown
+	PreviewRight       bool            // The preview pane goes beside the table instead of below it
+	PreviewOutput      string          // Output of the last preview run
+	PreviewErr         error           // Error of the last preview run, if it failed
+	PreviewRunning     bool            // A preview run is in flight
+	PreviewScroll      int             // First preview output line shown
+	PipeCSV            bool            // Pipe the table as CSV instead of TSV
+	OpenInEditor       bool            // v and V open $EDITOR instead of $PAGER
+	OpenRowJSON        bool            // V writes the row as JSON instead of YAML
+	Editing            bool            // Edit mode: keys edit cells and delete rows and columns
+	ShowChanges        bool            // The list of edits is shown over the table
+	ChangesScroll      int             // First line of the list of edits shown
+	PipeCommands       []string        // Commands the table was piped through, oldest first
 	ViewMode           ViewMode
 	ScrollOffset       int
 	TermWidth          int
 	TermHeight         int
 	AutoExpand         bool
+	Heatmap            bool // Color numeric cells on a gradient between the column's min and max
+	Theme              theme.Theme
+	Border             layout.BorderStyle
 	FilterInput        string
 	FilteredRowIndices []int
 	FilterColumnIndex  int
 	FilterScrollOffset int
-	ExportData         string // Data to export when quitting with 'o'
+	ExportData         string        // Data to export when quitting with 'o'
+	ExportFormat       export.Format // Format used by 'o'
+	ExportTemplate     string        // Go text/template rendering each row, used by the template format
+	ExportHeader       bool          // Include the header row in plain exports
+	ExportColumns      []string      // Columns to export by header name, in this order (empty: the visible columns)
+	WatchInterval      time.Duration
+	WatchPaused        bool
+	LastRefresh        time.Time
+	RefreshErr         error      // Error from the last refresh, if it failed
+	RowKey             []string   // Columns identifying a row across refreshes (first column when empty)
+	HighlightRefreshes int        // How many refreshes a change stays highlighted
+	Ghosts             [][]string // Rows removed by the last refreshes, shown struck-through after the table
+	History            []Snapshot // Past snapshots in watch mode, oldest first; the last one is live
+	HistorySize        int        // Maximum number of snapshots kept
+	HistoryBack        int        // How many snapshots back from the live one is shown (0 = live)
+	DiffBase           time.Time  // Time of the snapshot marked as base for diff exports (zero = none)
+	StreamOpen         bool       // The input stream is still being read
+	StreamErr          error      // Error that closed the input stream, if any
+	LiveStream         bool       // The input is a live stream (--stream): its state stays in the status line
+	Follow             bool       // Keep the view scrolled to the last row as rows arrive
+	LoadStart          time.Time  // When reading the input started
+	LoadedBytes        int64      // Input bytes read so far
+	LoadCancelled      bool       // Reading was stopped with Ctrl+C before the input ended
+	QuitOnEmptyInput   bool       // Quit when the input ends without any rows ("No data found")
+	Prompt             *Prompt    // Question being answered in place of the help line, nil when none
+	StatusMessage      string     // Result of the last command, shown in the help line until the next key
+	DiffFilter         DiffFilter // Rows shown when comparing two tables
+	MalformedLines     int        // Input lines with invalid UTF-8 or control characters, shown sanitized
+	FirstMalformedLine int        // Line number of the first malformed input line
+	SpinnerFrame       int
 	renderer           func(Model) string
+	refresher          Refresher
+	refreshing         bool
+	watchGen           int
+	changes            changeTracker
+	rowKeys            []string // Identity of each row, aligned with Rows
+	stream             <-chan StreamBatch
+	cancelStream       func()
+	loadTable          TableLoader
+	clipboard          Clipboard
+	pendingExport      *exportRequest // Export waiting for the overwrite confirmation
+	pendingAction      *pendingAction // Action waiting for its confirmation
+	screenWidth        int            // Terminal size; TermWidth and TermHeight leave out the preview pane
+	screenHeight       int
+	previewTarget      string // Preview command of the row under the cursor
+	previewGen         int    // Identifies the row previewed, so stale debounces and results are dropped
+	cancelPreview      context.CancelFunc
+	pipeHistory        []pipeStep   // Tables replaced by pipes, to go back to with u
+	pendingYank        bool         // y was pressed, waiting for what to copy
+	templateOnQuit     bool         // T set a template: the rows visible when quitting are printed through it
+	edits              *editState   // Original table and where its rows and columns went, nil without edits
+	tableDiff          *diff.Result // Comparison of two tables shown as the table, nil when not diffing
+	diffRows           []diff.Row   // Comparison row shown at each index of Rows
 }
 
 // New creates a new model with the given rows
@@ -32,8 +115,36 @@ func New(rows [][]string, termWidth, termHeight int) Model {
 		ScrollOffset:    0,
 		TermWidth:       termWidth,
 		TermHeight:      termHeight,
+		screenWidth:     termWidth,
+		screenHeight:    termHeight,
 		AutoExpand:      false,
+		Theme:           theme.Default(),
+		ExportFormat:    export.Plain,
+
+		HighlightRefreshes: DefaultHighlightRefreshes,
+		HistorySize:        DefaultHistorySize,
+	}
+}
+
+// DataRowIndices returns the indices of the data rows currently shown: the filter matches, or every data row
+func (m Model) DataRowIndices() []int {
+	if len(m.FilteredRowIndices) > 0 {
+		return m.FilteredRowIndices
+	}
+	var indices []int
+	for i := 1; i < len(m.Rows); i++ {
+		indices = append(indices, i)
 	}
+	return indices
+}
+
+// CellRows returns the rows to draw: the styled input cells when colors are kept, the plain cells otherwise
+// Filtering, width calculation and heatmap ranges always work on the plain Rows
+func (m Model) CellRows() [][]string {
+	if m.KeepColors && len(m.StyledRows) == len(m.Rows) {
+		return m.StyledRows
+	}
+	return m.Rows
 }
 
 // SetRenderer sets the view renderer function
@@ -43,7 +154,20 @@ func (m *Model) SetRenderer(renderer func(Model) string) {
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return nil
+	var cmds []tea.Cmd
+	if m.Watching() {
+		// Init can't modify the model, so the first tick uses the current generation
+		cmds = append(cmds, watchTick(m.WatchInterval, m.watchGen))
+	}
+	if m.Streaming() {
+		cmds = append(cmds, waitForBatch(m.stream), loadTick())
+	}
+	return tea.Batch(cmds...)
+}
+
+// CapturesKeys reports whether the model consumes every key itself, e.g. while typing a filter or a prompt
+func (m Model) CapturesKeys() bool {
+	return m.ViewMode == FilterView || m.Prompt != nil
 }
 
 // View renders the UI using the provided renderer
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/export"
+)
+
+// Commands opening a file given as $1; PAGER and EDITOR may carry arguments, so the shell expands them
+const (
+	pagerCommand  = `${PAGER:-less} "$1"`
+	editorCommand = `${VISUAL:-${EDITOR:-vi}} "$1"`
+)
+
+// openDoneMsg reports that the pager or editor was closed
+type openDoneMsg struct {
+	err error
+}
+
+// openCell shows the focused cell of the current row in full; JSON values are pretty-printed
+func (m Model) openCell() (tea.Model, tea.Cmd) {
+	rowIdx, ok := m.CurrentRowIndex()
+	if !ok || m.CurrentColumn >= len(m.Rows[0]) {
+		return m, nil
+	}
+
+	value := ""
+	if m.CurrentColumn < len(m.Rows[rowIdx]) {
+		value = m.Rows[rowIdx][m.CurrentColumn]
+	}
+	ext := ".txt"
+	if pretty, ok := export.PrettyJSON(value); ok {
+		value, ext = pretty, ".json"
+	}
+	return m.openText(value, ext)
+}
+
+// openRow shows every column of the current row, as YAML or as JSON when OpenRowJSON is set
+func (m Model) openRow() (tea.Model, tea.Cmd) {
+	rowIdx, ok := m.CurrentRowIndex()
+	if !ok {
+		return m, nil
+	}
+	if m.OpenRowJSON {
+		return m.openText(export.RecordJSON(m.Rows[0], m.Rows[rowIdx]), ".json")
+	}
+	return m.openText(export.RecordYAML(m.Rows[0], m.Rows[rowIdx]), ".yaml")
+}
+
+// openText writes text to a temporary file and opens it in $PAGER, or $EDITOR when OpenInEditor is set
+// The table is suspended meanwhile and comes back as it was; the file is removed afterwards
+func (m Model) openText(text, ext string) (tea.Model, tea.Cmd) {
+	f, err := os.CreateTemp("", "tablefy-*"+ext)
+	if err != nil {
+		m.StatusMessage = fmt.Sprintf("Open failed: %v", err)
+		return m, nil
+	}
+	_, err = f.WriteString(text + "\n")
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		m.StatusMessage = fmt.Sprintf("Open failed: %v", err)
+		return m, nil
+	}
+
+	script := pagerCommand
+	if m.OpenInEditor {
+		script = editorCommand
+	}
+	path := f.Name()
+	cmd := exec.Command("sh", "-c", script, "tablefy", path)
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		os.Remove(path)
+		return openDoneMsg{err: err}
+	})
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// openedFile presses the key and returns the name and content of the temporary file it opens
+func openedFile(t *testing.T, m Model, key string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+
+	if _, cmd := update(m, keyMsg(key)); cmd == nil {
+		t.Fatalf("%s should open a pager", key)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "tablefy-*"))
+	if len(files) != 1 {
+		t.Fatalf("%s wrote %d files, want 1", key, len(files))
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Base(files[0]), string(data)
+}
+
+func TestOpenCell(t *testing.T) {
+	m := New([][]string{{"NAME", "LABELS"}, {"web", `{"app":"web"}`}, {"db", "plain"}}, 80, 24)
+	m.CurrentColumn = 1
+
+	name, data := openedFile(t, m, "v")
+	if !strings.HasSuffix(name, ".json") || data != "{\n  \"app\": \"web\"\n}\n" {
+		t.Errorf("v opened %s with %q, want the pretty-printed JSON", name, data)
+	}
+
+	m = typeKeys(m, keyMsg("j"))
+	if name, data = openedFile(t, m, "v"); !strings.HasSuffix(name, ".txt") || data != "plain\n" {
+		t.Errorf("v opened %s with %q, want the plain value", name, data)
+	}
+}
+
+func TestOpenRow(t *testing.T) {
+	m := New([][]string{{"NAME", "LABELS"}, {"web", `{"app":"web"}`}}, 80, 24)
+
+	name, data := openedFile(t, m, "V")
+	if !strings.HasSuffix(name, ".yaml") || !strings.HasPrefix(data, "NAME: web\nLABELS: |-\n") {
+		t.Errorf("V opened %s with %q, want the row as YAML", name, data)
+	}
+
+	m.OpenRowJSON = true
+	if name, data = openedFile(t, m, "V"); !strings.HasSuffix(name, ".json") || !strings.Contains(data, `"LABELS": {`) {
+		t.Errorf("V opened %s with %q, want the row as JSON", name, data)
+	}
+
+	// A pager that fails is reported once the table is back
+	m, _ = update(m, openDoneMsg{err: errors.New("exit status 127")})
+	if !strings.Contains(m.StatusMessage, "exit status 127") {
+		t.Errorf("status %q should report the failure", m.StatusMessage)
+	}
+}
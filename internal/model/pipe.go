@@ -0,0 +1,149 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/export"
+	"tablefy/internal/input"
+	"tablefy/internal/parser"
+)
+
+// pipeStep is a table replaced by the output of a pipe, kept to go back to
+type pipeStep struct {
+	rows              [][]string
+	styled            [][]string
+	filterColumnIndex int
+	filterInput       string
+	filtered          []int
+	edits             *editState // Changes made in edit mode, so they can still be reverted after undoing the pipe
+}
+
+// pipeDoneMsg carries the output of a pipe command
+type pipeDoneMsg struct {
+	command string
+	output  string
+	err     error
+}
+
+// PipeFormat returns the format the visible table is piped in: TSV, or CSV when PipeCSV is set
+func (m Model) PipeFormat() export.Format {
+	if m.PipeCSV {
+		return export.CSV
+	}
+	return export.TSV
+}
+
+// openPipePrompt asks for the shell command to pipe the visible table through
+func (m *Model) openPipePrompt() {
+	m.openPrompt(PromptPipe, pipePromptLabel(m.PipeFormat()), "COMMAND, e.g. sort -t$'\\t' -k3 or awk -F'\\t' 'NR==1 || $3 > 5' | Tab: TSV/CSV")
+}
+
+// pipePromptLabel shows the format the table is piped in
+func pipePromptLabel(format export.Format) string {
+	return fmt.Sprintf("Pipe (%s) |", format)
+}
+
+// startPipe runs the command with the visible table, header first, on its stdin
+func (m Model) startPipe(command string) (tea.Model, tea.Cmd) {
+	switch {
+	case strings.TrimSpace(command) == "":
+		return m, nil
+	case m.Watching():
+		m.StatusMessage = "Pipe is not available in watch mode: refreshes would replace the result"
+		return m, nil
+	case m.Diffing():
+		m.StatusMessage = "Pipe is not available while comparing tables"
+		return m, nil
+	case m.StreamOpen:
+		m.StatusMessage = "Wait for the input to finish loading before piping"
+		return m, nil
+	}
+
+	// The command sees the columns and rows on screen, without colors
+	visible := m
+	visible.ExportColumns = nil
+	visible.ExportColors = false
+	table := visible.ExportTable()
+	if len(table.Header) == 0 {
+		m.StatusMessage = "Nothing to pipe"
+		return m, nil
+	}
+	// TSV and CSV exports can't fail
+	data, _ := export.Export(m.PipeFormat(), table, export.Options{})
+
+	m.StatusMessage = fmt.Sprintf("Running %s...", command)
+	return m, func() tea.Msg {
+		cmd := exec.Command("sh", "-c", command)
+		cmd.Stdin = strings.NewReader(data + "\n")
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, strings.SplitN(msg, "\n", 2)[0])
+			}
+		}
+		return pipeDoneMsg{command: command, output: input.SanitizeText(string(output)), err: err}
+	}
+}
+
+// handlePipeDone replaces the table with the parsed output of the pipe, keeping the previous table to go back to
+func (m Model) handlePipeDone(msg pipeDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.StatusMessage = fmt.Sprintf("Pipe failed: %v", msg.err)
+		return m, nil
+	}
+	rows, styled := parser.Parse(msg.output, parser.FormatAuto)
+	if len(rows) == 0 {
+		m.StatusMessage = fmt.Sprintf("Pipe failed: %s printed no table", msg.command)
+		return m, nil
+	}
+
+	m.pipeHistory = append(m.pipeHistory, pipeStep{
+		rows:              m.Rows,
+		styled:            m.StyledRows,
+		filterColumnIndex: m.FilterColumnIndex,
+		filterInput:       m.FilterInput,
+		filtered:          m.FilteredRowIndices,
+		edits:             m.edits,
+	})
+	m.PipeCommands = append(append([]string(nil), m.PipeCommands...), msg.command)
+
+	// The piped table holds the filtered rows already, and the edited values as its original ones
+	m.forgetEdits()
+	m.ClearFilter()
+	m.ViewMode = NormalView
+	m.Ghosts = nil
+	m.ReplaceRows(rows, styled)
+	m.CursorRow = 0
+	m.ScrollOffset = 0
+	m.StatusMessage = fmt.Sprintf("%s: %d rows (u: back)", msg.command, len(rows)-1)
+	return m, nil
+}
+
+// undoPipe goes back to the table before the last pipe
+func (m *Model) undoPipe() {
+	if len(m.pipeHistory) == 0 {
+		return
+	}
+	step := m.pipeHistory[len(m.pipeHistory)-1]
+	m.pipeHistory = m.pipeHistory[:len(m.pipeHistory)-1]
+	m.PipeCommands = m.PipeCommands[:len(m.PipeCommands)-1]
+
+	m.forgetEdits()
+	m.edits = step.edits
+	m.ClearFilter()
+	m.ViewMode = NormalView
+	m.ReplaceRows(step.rows, step.styled)
+	m.FilterColumnIndex = step.filterColumnIndex
+	m.FilterInput = step.filterInput
+	m.FilteredRowIndices = step.filtered
+	m.CursorRow = 0
+	m.ScrollOffset = 0
+	m.StatusMessage = fmt.Sprintf("Back to the table before the pipe (%d rows)", len(step.rows)-1)
+}
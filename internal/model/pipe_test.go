@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pipe types a command in the pipe prompt and runs it
+func pipe(m Model, command string) Model {
+	m = typeKeys(m, keyMsg("|"))
+	m = typeKeys(m, typeText(command)...)
+	return typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestPipe(t *testing.T) {
+	rows := [][]string{{"NAME", "RESTARTS"}, {"web", "0"}, {"db", "4"}, {"cache", "7"}}
+	m := New(rows, 80, 24)
+
+	m = pipe(m, "awk -F'\\t' 'NR==1 || $2 > 1'")
+	want := [][]string{{"NAME", "RESTARTS"}, {"db", "4"}, {"cache", "7"}}
+	if fmt.Sprint(m.Rows) != fmt.Sprint(want) {
+		t.Fatalf("piped rows = %v, want %v (status %q)", m.Rows, want, m.StatusMessage)
+	}
+
+	// Pipes chain, and the piped table only holds the filtered rows
+	m.FilterColumnIndex = 0
+	m.FilteredRowIndices = ApplyFuzzyFilter(m.Rows, 0, "cache")
+	m = pipe(m, "cut -f1")
+	if fmt.Sprint(m.Rows) != "[[NAME] [cache]]" || len(m.FilteredRowIndices) != 0 {
+		t.Fatalf("piped rows = %v, filter %v; want the filtered NAME column", m.Rows, m.FilteredRowIndices)
+	}
+	if got := strings.Join(m.PipeCommands, " | "); got != "awk -F'\\t' 'NR==1 || $2 > 1' | cut -f1" {
+		t.Errorf("PipeCommands = %q", got)
+	}
+
+	// u steps back through the pipeline, filter included
+	m = typeKeys(m, keyMsg("u"))
+	if fmt.Sprint(m.Rows) != fmt.Sprint(want) || len(m.FilteredRowIndices) != 1 {
+		t.Errorf("after u: rows %v, filter %v; want the filtered first pipe", m.Rows, m.FilteredRowIndices)
+	}
+	m = typeKeys(m, keyMsg("u"), keyMsg("u"))
+	if fmt.Sprint(m.Rows) != fmt.Sprint(rows) || len(m.PipeCommands) != 0 {
+		t.Errorf("after uu: rows %v, commands %v; want the original table", m.Rows, m.PipeCommands)
+	}
+}
+
+func TestPipeCSV(t *testing.T) {
+	m := New([][]string{{"NAME", "NOTE"}, {"web", "a, b"}}, 80, 24)
+
+	// Tab switches the prompt to CSV
+	m = typeKeys(m, keyMsg("|"), tea.KeyMsg{Type: tea.KeyTab})
+	if !m.PipeCSV || !strings.Contains(m.Prompt.Label, "csv") {
+		t.Fatalf("Tab should pipe as CSV, label %q", m.Prompt.Label)
+	}
+	m = typeKeys(m, typeText("cat")...)
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if fmt.Sprint(m.Rows) != "[[NAME NOTE] [web a, b]]" {
+		t.Errorf("rows = %q, want the CSV parsed back", m.Rows)
+	}
+}
+
+func TestPipeErrors(t *testing.T) {
+	rows := [][]string{{"NAME"}, {"web"}}
+	m := New(rows, 80, 24)
+
+	m = pipe(m, "echo broken >&2; exit 3")
+	if !strings.Contains(m.StatusMessage, "broken") || len(m.PipeCommands) != 0 {
+		t.Errorf("status %q should report the command's error", m.StatusMessage)
+	}
+
+	m = pipe(m, "true")
+	if !strings.Contains(m.StatusMessage, "no table") || fmt.Sprint(m.Rows) != fmt.Sprint(rows) {
+		t.Errorf("status %q, rows %v; an empty output should keep the table", m.StatusMessage, m.Rows)
+	}
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/action"
+	"tablefy/internal/input"
+)
+
+// previewDebounce is how long the cursor has to rest on a row before its preview runs
+const previewDebounce = 150 * time.Millisecond
+
+// previewKillDelay is how long a cancelled preview may keep its output open, e.g. through a child process
+const previewKillDelay = time.Second
+
+// previewDebounceMsg signals that the cursor rested long enough; gen identifies the row it rested on
+type previewDebounceMsg struct {
+	gen int
+}
+
+// previewResultMsg carries the output of a preview run
+type previewResultMsg struct {
+	gen    int
+	output string
+	err    error
+}
+
+// SetPreview enables the preview pane: command runs for the row under the cursor, with {HEADER} placeholders
+// The pane goes beside the table when right is set, below it otherwise
+func (m *Model) SetPreview(command string, right bool) {
+	m.PreviewCommand = command
+	m.PreviewRight = right
+	m.PreviewVisible = true
+	m.layoutPreview()
+}
+
+// Previewing reports whether the preview pane is shown
+func (m Model) Previewing() bool {
+	return m.PreviewCommand != "" && m.PreviewVisible
+}
+
+// resize records the screen size and splits it between the table and the preview pane
+func (m *Model) resize(width, height int) {
+	m.screenWidth, m.screenHeight = width, height
+	m.layoutPreview()
+}
+
+// layoutPreview gives the table the screen minus the preview pane; the views only see the table's size
+func (m *Model) layoutPreview() {
+	if m.screenWidth == 0 && m.screenHeight == 0 {
+		m.screenWidth, m.screenHeight = m.TermWidth, m.TermHeight
+	}
+	m.TermWidth, m.TermHeight = m.screenWidth, m.screenHeight
+	if !m.Previewing() {
+		return
+	}
+	if m.PreviewRight {
+		m.TermWidth = m.screenWidth - m.screenWidth/2
+	} else {
+		m.TermHeight = m.screenHeight - max(m.screenHeight*2/5, 5)
+	}
+}
+
+// PreviewSize returns the width and height of the preview pane
+func (m Model) PreviewSize() (width, height int) {
+	if m.PreviewRight {
+		return m.screenWidth - m.TermWidth, m.screenHeight
+	}
+	return m.screenWidth, m.screenHeight - m.TermHeight
+}
+
+// previewTargetCommand returns the preview command for the row under the cursor, empty without a row
+func (m Model) previewTargetCommand() string {
+	rowIdx, ok := m.CurrentRowIndex()
+	if !ok {
+		return ""
+	}
+	return action.Action{Command: m.PreviewCommand}.Expand(m.Rows[0], m.Rows[rowIdx])
+}
+
+// followPreview schedules a preview run when the row under the cursor changed, cancelling the one in flight
+func (m Model) followPreview(cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	target := m.previewTargetCommand()
+	if target == m.previewTarget {
+		return m, cmd
+	}
+
+	m.previewTarget = target
+	m.previewGen++
+	m.stopPreview()
+	m.PreviewScroll = 0
+	if target == "" {
+		m.PreviewOutput, m.PreviewErr = "", nil
+		return m, cmd
+	}
+
+	gen := m.previewGen
+	debounce := tea.Tick(previewDebounce, func(time.Time) tea.Msg {
+		return previewDebounceMsg{gen: gen}
+	})
+	return m, tea.Batch(cmd, debounce)
+}
+
+// stopPreview cancels the preview run in flight, if any
+func (m *Model) stopPreview() {
+	if m.cancelPreview != nil {
+		m.cancelPreview()
+		m.cancelPreview = nil
+	}
+	m.PreviewRunning = false
+}
+
+// handlePreviewDebounce runs the preview once the cursor rested on the row
+func (m Model) handlePreviewDebounce(msg previewDebounceMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != m.previewGen || !m.Previewing() {
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelPreview = cancel
+	m.PreviewRunning = true
+	command, gen := m.previewTarget, m.previewGen
+	return m, func() tea.Msg {
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.WaitDelay = previewKillDelay
+		output, err := cmd.CombinedOutput()
+		return previewResultMsg{gen: gen, output: input.SanitizeText(string(output)), err: err}
+	}
+}
+
+// handlePreviewResult shows the output of the current row's preview; results for rows left behind are dropped
+func (m Model) handlePreviewResult(msg previewResultMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != m.previewGen {
+		return m, nil
+	}
+	m.stopPreview()
+	m.PreviewOutput = msg.output
+	m.PreviewErr = msg.err
+	return m, nil
+}
+
+// togglePreview shows or hides the preview pane
+func (m *Model) togglePreview() {
+	m.PreviewVisible = !m.PreviewVisible
+	if !m.PreviewVisible {
+		// Forget the row so showing the pane again runs a fresh preview
+		m.stopPreview()
+		m.previewTarget = ""
+		m.previewGen++
+	}
+	m.layoutPreview()
+	m.ScrollOffset = min(m.ScrollOffset, m.GetMaxScroll())
+}
+
+// scrollPreview scrolls the preview output by delta lines
+func (m *Model) scrollPreview(delta int) {
+	m.PreviewScroll = max(m.PreviewScroll+delta, 0)
+}
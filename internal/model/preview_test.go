@@ -0,0 +1,93 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// update sends a message to the model and returns the model with the command it returned
+func update(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	next, cmd := m.Update(msg)
+	return next.(Model), cmd
+}
+
+func TestPreviewFollowsCursor(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web"}, {"db"}}, 80, 30)
+	m.SetPreview("echo {NAME}", false)
+
+	m, cmd := update(m, tea.WindowSizeMsg{Width: 80, Height: 30})
+	if cmd == nil {
+		t.Fatal("the first row should schedule a preview")
+	}
+	first := m.previewGen
+
+	// Moving on before the debounce fires drops the first row's run
+	m, _ = update(m, keyMsg("j"))
+	if m.previewGen == first {
+		t.Fatal("moving the cursor should start a new preview")
+	}
+	if _, cmd = update(m, previewDebounceMsg{gen: first}); cmd != nil {
+		t.Error("a stale debounce should not run the preview")
+	}
+
+	m, cmd = update(m, previewDebounceMsg{gen: m.previewGen})
+	if cmd == nil || !m.PreviewRunning {
+		t.Fatal("the debounce should run the preview")
+	}
+	m, _ = update(m, cmd())
+	if m.PreviewOutput != "db\n" || m.PreviewErr != nil || m.PreviewRunning {
+		t.Errorf("PreviewOutput = %q, err %v, running %v; want the second row's output", m.PreviewOutput, m.PreviewErr, m.PreviewRunning)
+	}
+
+	// Results of rows left behind are ignored
+	m, _ = update(m, previewResultMsg{gen: first, output: "web\n"})
+	if m.PreviewOutput != "db\n" {
+		t.Errorf("a stale result replaced the output: %q", m.PreviewOutput)
+	}
+
+	// Staying on the row doesn't run the preview again
+	if _, cmd = update(m, keyMsg("b")); cmd != nil {
+		t.Error("the preview should only run when the row changes")
+	}
+}
+
+func TestPreviewCancel(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web"}, {"db"}}, 80, 30)
+	m.SetPreview("sleep 10; echo {NAME}", false)
+	m, _ = update(m, tea.WindowSizeMsg{Width: 80, Height: 30})
+	m, cmd := update(m, previewDebounceMsg{gen: m.previewGen})
+
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	update(m, keyMsg("j"))
+
+	select {
+	case msg := <-done:
+		if result := msg.(previewResultMsg); result.err == nil {
+			t.Error("a cancelled preview should report an error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("moving the cursor should cancel the running preview")
+	}
+}
+
+func TestPreviewLayout(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web"}}, 80, 30)
+	m.SetPreview("echo {NAME}", false)
+	if w, h := m.PreviewSize(); m.TermHeight != 18 || w != 80 || h != 12 {
+		t.Errorf("bottom pane: table height %d, pane %dx%d; want 18 and 80x12", m.TermHeight, w, h)
+	}
+
+	m = typeKeys(m, keyMsg("P"))
+	if m.Previewing() || m.TermHeight != 30 {
+		t.Errorf("P should hide the pane and give the table the screen, got height %d", m.TermHeight)
+	}
+
+	m.PreviewRight = true
+	m = typeKeys(m, keyMsg("P"))
+	if w, h := m.PreviewSize(); m.TermWidth != 40 || m.TermHeight != 30 || w != 40 || h != 30 {
+		t.Errorf("right pane: table %dx%d, pane %dx%d; want 40x30 and 40x30", m.TermWidth, m.TermHeight, w, h)
+	}
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PromptKind identifies what the answer to a prompt is used for
+type PromptKind int
+
+const (
+	PromptJoin      PromptKind = iota // File and keys to join into the table
+	PromptExport                      // Format and destination to export the visible table to
+	PromptOverwrite                   // Confirmation before an export replaces an existing file
+	PromptTemplate                    // Go template rendering each exported row
+	PromptAction                      // Name of the action to run on the rows
+	PromptRunAction                   // Confirmation before running an action
+	PromptPipe                        // Shell command to pipe the visible table through
+	PromptEdit                        // New value of the focused cell
+	PromptRevert                      // Confirmation before reverting every edit
+)
+
+// Prompt is a one-line question shown in place of the help line
+type Prompt struct {
+	Kind    PromptKind
+	Label   string // Question shown before the input
+	Hint    string // Expected answer, shown after the input
+	Input   string
+	Confirm bool // A yes/no question answered with a single key: y confirms, anything else cancels
+}
+
+// openPrompt starts asking a question; every key goes to the prompt until it is answered or cancelled
+func (m *Model) openPrompt(kind PromptKind, label, hint string) {
+	m.Prompt = &Prompt{Kind: kind, Label: label, Hint: hint}
+}
+
+// openConfirm asks a yes/no question
+func (m *Model) openConfirm(kind PromptKind, question string) {
+	m.Prompt = &Prompt{Kind: kind, Label: question, Hint: "y: Yes | any other key: No", Confirm: true}
+}
+
+// handlePromptInput edits the prompt input; Enter submits the answer and Esc cancels
+func (m Model) handlePromptInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The prompt is shared with copies of the model, so edit a copy
+	prompt := *m.Prompt
+
+	if prompt.Confirm {
+		m.Prompt = nil
+		if msg.String() == "y" || msg.String() == "Y" {
+			return m.submitPrompt(prompt)
+		}
+		m.StatusMessage = "Cancelled"
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.Prompt = nil
+		return m, nil
+	case tea.KeyEnter:
+		m.Prompt = nil
+		return m.submitPrompt(prompt)
+	case tea.KeyBackspace:
+		if runes := []rune(prompt.Input); len(runes) > 0 {
+			prompt.Input = string(runes[:len(runes)-1])
+		}
+	case tea.KeyTab:
+		// Include the header in plain exports or not
+		if prompt.Kind == PromptExport {
+			m.ExportHeader = !m.ExportHeader
+			prompt.Label = exportPromptLabel(m.ExportHeader)
+		}
+		// Pipe the table as TSV or CSV
+		if prompt.Kind == PromptPipe {
+			m.PipeCSV = !m.PipeCSV
+			prompt.Label = pipePromptLabel(m.PipeFormat())
+		}
+	case tea.KeySpace:
+		prompt.Input += " "
+	case tea.KeyRunes:
+		prompt.Input += string(msg.Runes)
+	}
+
+	m.Prompt = &prompt
+	return m, nil
+}
+
+// submitPrompt acts on the answer to a prompt
+func (m Model) submitPrompt(prompt Prompt) (tea.Model, tea.Cmd) {
+	switch prompt.Kind {
+	case PromptJoin:
+		return m.startJoin(prompt.Input)
+	case PromptExport:
+		return m.startExport(prompt.Input)
+	case PromptTemplate:
+		return m.applyTemplate(prompt.Input)
+	case PromptPipe:
+		return m.startPipe(prompt.Input)
+	case PromptEdit:
+		return m.applyEdit(prompt.Input)
+	case PromptRevert:
+		m.revertEdits()
+	case PromptAction:
+		a, err := m.findAction(prompt.Input)
+		if err != nil {
+			m.StatusMessage = err.Error()
+			return m, nil
+		}
+		return m.runAction(a)
+	case PromptRunAction:
+		if m.pendingAction != nil {
+			pending := *m.pendingAction
+			m.pendingAction = nil
+			return m, m.execAction(pending.action, pending.rows)
+		}
+	case PromptOverwrite:
+		if m.pendingExport != nil {
+			request := *m.pendingExport
+			m.pendingExport = nil
+			return m, writeExportFile(request)
+		}
+	}
+	return m, nil
+}
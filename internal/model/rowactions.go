@@ -0,0 +1,115 @@
+package model
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/action"
+)
+
+// pendingAction is an action waiting for its confirmation
+type pendingAction struct {
+	action action.Action
+	rows   []int
+}
+
+// actionDoneMsg reports that an action's command finished and the table is back
+type actionDoneMsg struct {
+	name string
+	rows int
+	err  error
+}
+
+// actionForKey returns the action bound to a key
+func (m Model) actionForKey(key string) (action.Action, bool) {
+	for _, a := range m.Actions {
+		if a.Key != "" && a.Key == key {
+			return a, true
+		}
+	}
+	return action.Action{}, false
+}
+
+// findAction returns the action with the given name, or the only one starting with it
+func (m Model) findAction(name string) (action.Action, error) {
+	name = strings.TrimSpace(name)
+	var matches []action.Action
+	for _, a := range m.Actions {
+		if a.Name == name {
+			return a, nil
+		}
+		if strings.HasPrefix(a.Name, name) {
+			matches = append(matches, a)
+		}
+	}
+	switch {
+	case name == "" || len(matches) == 0:
+		return action.Action{}, fmt.Errorf("no action %q", name)
+	case len(matches) > 1:
+		return action.Action{}, fmt.Errorf("%q matches several actions", name)
+	}
+	return matches[0], nil
+}
+
+// openActionMenu asks which action to run
+func (m *Model) openActionMenu() {
+	var names []string
+	for _, a := range m.Actions {
+		if a.Key != "" {
+			names = append(names, fmt.Sprintf("%s (%s)", a.Name, a.Key))
+		} else {
+			names = append(names, a.Name)
+		}
+	}
+	m.openPrompt(PromptAction, "Run", strings.Join(names, ", "))
+}
+
+// runAction runs an action on the selected rows, or on the current row, asking first when it needs confirming
+func (m Model) runAction(a action.Action) (tea.Model, tea.Cmd) {
+	rows := m.TargetRows()
+	if len(rows) == 0 {
+		m.StatusMessage = fmt.Sprintf("No row to run %s on", a.Name)
+		return m, nil
+	}
+
+	if a.Confirm {
+		m.pendingAction = &pendingAction{action: a, rows: rows}
+		first := a.Expand(m.Rows[0], m.Rows[rows[0]])
+		question := fmt.Sprintf("Run %s? %s", a.Name, first)
+		if len(rows) > 1 {
+			question = fmt.Sprintf("Run %s on %d rows? First: %s", a.Name, len(rows), first)
+		}
+		m.openConfirm(PromptRunAction, question)
+		return m, nil
+	}
+	return m, m.execAction(a, rows)
+}
+
+// execAction suspends the UI and runs the action's command for every row, in order
+func (m Model) execAction(a action.Action, rows []int) tea.Cmd {
+	var values [][]string
+	for _, rowIdx := range rows {
+		values = append(values, m.Rows[rowIdx])
+	}
+
+	cmd := exec.Command("sh", "-c", a.Script(m.Rows[0], values))
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return actionDoneMsg{name: a.Name, rows: len(rows), err: err}
+	})
+}
+
+// handleActionDone reports how the action went once the table is back
+func (m Model) handleActionDone(msg actionDoneMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		m.StatusMessage = fmt.Sprintf("%s failed: %v", msg.name, msg.err)
+	case msg.rows == 1:
+		m.StatusMessage = fmt.Sprintf("Ran %s", msg.name)
+	default:
+		m.StatusMessage = fmt.Sprintf("Ran %s on %d rows", msg.name, msg.rows)
+	}
+	return m, nil
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/action"
+)
+
+func TestRowCursor(t *testing.T) {
+	rows := [][]string{{"NAME"}}
+	for i := 0; i < 30; i++ {
+		rows = append(rows, []string{fmt.Sprintf("pod-%d", i)})
+	}
+	m := New(rows, 80, 12) // 6 visible rows
+
+	m = typeKeys(m, keyMsg("j"), keyMsg("j"))
+	if row, _ := m.CurrentRowIndex(); row != 3 || m.ScrollOffset != 0 {
+		t.Errorf("after jj: row %d, offset %d; want row 3, offset 0", row, m.ScrollOffset)
+	}
+
+	// Moving past the page scrolls
+	for i := 0; i < 5; i++ {
+		m = typeKeys(m, keyMsg("j"))
+	}
+	if row, _ := m.CurrentRowIndex(); row != 8 || m.ScrollOffset != 2 {
+		t.Errorf("row %d, offset %d; want row 8, offset 2", row, m.ScrollOffset)
+	}
+
+	// Scrolling the page moves the cursor along
+	m.ScrollOffset = 20
+	if row, _ := m.CurrentRowIndex(); row != 21 {
+		t.Errorf("cursor should stay on the page, got row %d", row)
+	}
+
+	// The cursor stays on the filtered rows shown
+	m.FilterColumnIndex = 0
+	m.FilteredRowIndices = ApplyFuzzyFilter(m.Rows, 0, "pod-2")
+	m.ScrollOffset = 0
+	if pos := m.CursorPosition(); pos != 5 {
+		t.Errorf("CursorPosition = %d, want the last row of the page (5)", pos)
+	}
+}
+
+func TestRowSelection(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web"}, {"db"}, {"cache"}}, 80, 24)
+
+	if rows := m.TargetRows(); fmt.Sprint(rows) != "[1]" {
+		t.Errorf("TargetRows = %v, want the current row", rows)
+	}
+
+	m = typeKeys(m, keyMsg("j"), keyMsg("x"), keyMsg("x"))
+	if rows := m.TargetRows(); fmt.Sprint(rows) != "[2 3]" {
+		t.Errorf("TargetRows = %v, want the selected rows", rows)
+	}
+
+	// Selected rows follow their key when the rows change
+	m.ReplaceRows([][]string{{"NAME"}, {"cache"}, {"web"}, {"db"}}, nil)
+	if rows := m.TargetRows(); fmt.Sprint(rows) != "[1 3]" {
+		t.Errorf("TargetRows after refresh = %v, want [1 3]", rows)
+	}
+}
+
+func TestRunActionConfirm(t *testing.T) {
+	m := New([][]string{{"NAME", "NAMESPACE"}, {"web", "prod"}}, 80, 24)
+	m.Actions = []action.Action{
+		{Name: "describe", Key: "ctrl+d", Command: "kubectl describe pod {NAME}"},
+		{Name: "delete", Key: "X", Command: "kubectl delete pod {NAME} -n {NAMESPACE}", Confirm: true},
+	}
+
+	// A confirmed action asks first, showing the command
+	m = typeKeys(m, keyMsg("X"))
+	if m.Prompt == nil || !m.Prompt.Confirm || !strings.Contains(m.Prompt.Label, "kubectl delete pod web -n prod") {
+		t.Fatalf("expected a confirmation showing the command, got %+v", m.Prompt)
+	}
+	next, cmd := m.Update(keyMsg("n"))
+	if cmd != nil || next.(Model).StatusMessage != "Cancelled" {
+		t.Error("declining should not run anything")
+	}
+
+	next, cmd = m.Update(keyMsg("y"))
+	m = next.(Model)
+	if cmd == nil || m.Prompt != nil || m.pendingAction != nil {
+		t.Error("confirming should run the action")
+	}
+
+	// Bound keys run right away
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD}); cmd == nil {
+		t.Error("ctrl+d should run describe")
+	}
+
+	// The menu finds actions by name or unique prefix
+	m = typeKeys(m, keyMsg("a"))
+	if m.Prompt == nil || !strings.Contains(m.Prompt.Hint, "describe (ctrl+d)") {
+		t.Fatalf("expected the action menu, got %+v", m.Prompt)
+	}
+	m = typeKeys(m, typeText("de")...)
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.StatusMessage, "several actions") {
+		t.Errorf("status %q should report the ambiguous name", m.StatusMessage)
+	}
+	if a, err := m.findAction("desc"); err != nil || a.Name != "describe" {
+		t.Errorf("findAction(desc) = %+v, %v", a, err)
+	}
+
+	next, _ = m.Update(actionDoneMsg{name: "describe", rows: 2})
+	if got := next.(Model).StatusMessage; got != "Ran describe on 2 rows" {
+		t.Errorf("status %q", got)
+	}
+}
+
+func TestBoundKeyTypedIntoFilter(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web-x"}, {"db"}}, 80, 24)
+	m.Actions = []action.Action{{Name: "delete", Key: "x", Command: "kubectl delete pod {NAME}", Confirm: true}}
+
+	m = typeKeys(m, keyMsg("f"), keyMsg("x"))
+	if m.FilterInput != "x" || m.Prompt != nil {
+		t.Fatalf("x should go into the filter, got input %q and prompt %+v", m.FilterInput, m.Prompt)
+	}
+	if len(m.FilteredRowIndices) != 1 || m.FilteredRowIndices[0] != 1 {
+		t.Errorf("FilteredRowIndices = %v, want only web-x", m.FilteredRowIndices)
+	}
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loadTickInterval is how often the loading spinner advances
+const loadTickInterval = 100 * time.Millisecond
+
+// StreamBatch carries rows parsed from input that is still being read
+// The first batch of a stream starts with the header row
+type StreamBatch struct {
+	Rows       [][]string
+	StyledRows [][]string // Aligned with Rows; nil when no cell has ANSI escapes
+	Bytes      int64      // Input bytes consumed to produce this batch
+	Err        error      // Read error; the stream closes after it
+
+	MalformedLines     int // Input lines sanitized so far (invalid UTF-8, control characters)
+	FirstMalformedLine int // Line number of the first sanitized line
+}
+
+// streamBatchMsg delivers the next batch, or reports that the stream closed
+type streamBatchMsg struct {
+	batch StreamBatch
+	open  bool
+}
+
+// loadTickMsg advances the loading spinner
+type loadTickMsg struct{}
+
+// SetStream makes the model load its rows in the background: batches are appended as they arrive
+// and the stream is closed when the channel is closed. cancel stops the reader; it may be nil.
+func (m *Model) SetStream(batches <-chan StreamBatch, cancel func()) {
+	m.stream = batches
+	m.cancelStream = cancel
+	m.StreamOpen = true
+	m.LoadStart = time.Now()
+}
+
+// Streaming reports whether the rows come from a stream
+func (m Model) Streaming() bool {
+	return m.stream != nil
+}
+
+// waitForBatch waits for the next batch of the stream
+func waitForBatch(batches <-chan StreamBatch) tea.Cmd {
+	return func() tea.Msg {
+		batch, open := <-batches
+		return streamBatchMsg{batch: batch, open: open}
+	}
+}
+
+// loadTick schedules the next spinner frame
+func loadTick() tea.Cmd {
+	return tea.Tick(loadTickInterval, func(time.Time) tea.Msg {
+		return loadTickMsg{}
+	})
+}
+
+// handleStreamBatch appends a batch and waits for the next one
+// A load that ends without any rows quits when QuitOnEmptyInput is set
+func (m Model) handleStreamBatch(msg streamBatchMsg) (tea.Model, tea.Cmd) {
+	if m.LoadCancelled {
+		// Whatever the reader still had in flight is dropped
+		return m, nil
+	}
+	if !msg.open {
+		m.StreamOpen = false
+		if len(m.Rows) == 0 && m.QuitOnEmptyInput && m.StreamErr == nil {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+	if msg.batch.Err != nil {
+		m.StreamErr = msg.batch.Err
+	}
+	m.LoadedBytes += msg.batch.Bytes
+	if msg.batch.MalformedLines > 0 {
+		m.MalformedLines, m.FirstMalformedLine = msg.batch.MalformedLines, msg.batch.FirstMalformedLine
+	}
+	m.AppendRows(msg.batch.Rows, msg.batch.StyledRows)
+	return m, waitForBatch(m.stream)
+}
+
+// handleLoadTick advances the spinner while the input is being read
+func (m Model) handleLoadTick() (tea.Model, tea.Cmd) {
+	if !m.StreamOpen {
+		return m, nil
+	}
+	m.SpinnerFrame++
+	return m, loadTick()
+}
+
+// cancelLoad stops reading the input, keeping the rows loaded so far
+func (m *Model) cancelLoad() {
+	if m.cancelStream != nil {
+		m.cancelStream()
+	}
+	m.StreamOpen = false
+	m.LoadCancelled = true
+}
+
+// AppendRows adds rows at the end of the table; the first rows of an empty table start with the header
+// An active filter is applied to the new rows, and in follow mode the view scrolls to the last row
+func (m *Model) AppendRows(rows, styled [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	if styled == nil {
+		styled = rows
+	}
+
+	start := len(m.Rows)
+	if len(m.StyledRows) == start {
+		m.StyledRows = append(m.StyledRows, styled...)
+	}
+	m.Rows = append(m.Rows, rows...)
+
+	// Only the new rows need matching; their indices follow the existing matches
+	if start > 0 && (len(m.FilteredRowIndices) > 0 || m.ViewMode == FilterView) {
+		matches := ApplyFuzzyFilter(append([][]string{m.Rows[0]}, rows...), m.FilterColumnIndex, m.FilterInput)
+		for _, idx := range matches {
+			m.FilteredRowIndices = append(m.FilteredRowIndices, start+idx-1)
+		}
+	}
+
+	if m.Follow {
+		m.scrollToEnd()
+	}
+}
+
+// scrollToEnd scrolls so the last row is visible and puts the cursor on it
+func (m *Model) scrollToEnd() {
+	m.CursorRow = len(m.DataRowIndices()) - 1
+	m.ScrollOffset = m.GetMaxScroll()
+	m.FilterScrollOffset = m.GetMaxFilterScroll()
+}
+
+// toggleFollow turns follow mode on (jumping to the last row) or off
+func (m *Model) toggleFollow() {
+	m.Follow = !m.Follow
+	if m.Follow {
+		m.scrollToEnd()
+	}
+}
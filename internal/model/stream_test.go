@@ -0,0 +1,130 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMsg builds the key message for a typed character
+func keyMsg(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestAppendRowsStartsWithHeader(t *testing.T) {
+	m := New(nil, 80, 24)
+	m.AppendRows([][]string{{"NAME", "STATUS"}, {"web", "Running"}}, nil)
+	m.AppendRows([][]string{{"db", "Pending"}}, [][]string{{"db", "\x1b[33mPending\x1b[0m"}})
+
+	if len(m.Rows) != 3 || m.Rows[0][0] != "NAME" {
+		t.Fatalf("unexpected rows %v", m.Rows)
+	}
+	if len(m.StyledRows) != 3 || m.StyledRows[2][1] != "\x1b[33mPending\x1b[0m" {
+		t.Errorf("styled rows not kept aligned: %q", m.StyledRows)
+	}
+}
+
+func TestAppendRowsExtendsFilter(t *testing.T) {
+	m := New([][]string{{"NAME", "STATUS"}, {"web", "Running"}, {"db", "Pending"}}, 80, 24)
+	m.FilterColumnIndex = 1
+	m.FilterInput = "run"
+	m.FilteredRowIndices = ApplyFuzzyFilter(m.Rows, 1, "run")
+
+	m.AppendRows([][]string{{"cache", "Pending"}, {"queue", "Running"}}, nil)
+
+	if fmt.Sprint(m.FilteredRowIndices) != "[1 4]" {
+		t.Errorf("FilteredRowIndices = %v, want [1 4]", m.FilteredRowIndices)
+	}
+}
+
+func TestFollowScrollsToNewRows(t *testing.T) {
+	m := New([][]string{{"N"}}, 80, 10)
+	m.Follow = true
+	for i := range 20 {
+		m.AppendRows([][]string{{fmt.Sprint(i)}}, nil)
+	}
+	if m.ScrollOffset != m.GetMaxScroll() || m.ScrollOffset == 0 {
+		t.Errorf("ScrollOffset = %d, want %d", m.ScrollOffset, m.GetMaxScroll())
+	}
+
+	// Scrolling up leaves follow mode, so new rows no longer move the view
+	next, _ := m.Update(keyMsg("k"))
+	m = next.(Model)
+	offset := m.ScrollOffset
+	m.AppendRows([][]string{{"new"}}, nil)
+	if m.Follow || m.ScrollOffset != offset {
+		t.Errorf("view moved after leaving follow mode: Follow=%v offset %d → %d", m.Follow, offset, m.ScrollOffset)
+	}
+}
+
+func TestStreamBatches(t *testing.T) {
+	batches := make(chan StreamBatch, 2)
+	batches <- StreamBatch{Rows: [][]string{{"NAME"}, {"web"}}}
+	batches <- StreamBatch{Err: errors.New("read failed")}
+	close(batches)
+
+	m := New(nil, 80, 24)
+	m.SetStream(batches, nil)
+	var model Model = m
+	cmd := waitForBatch(batches)
+	for cmd != nil {
+		next, nextCmd := model.Update(cmd())
+		model, cmd = next.(Model), nextCmd
+	}
+
+	if model.StreamOpen {
+		t.Error("stream should be closed after the channel closes")
+	}
+	if model.StreamErr == nil || len(model.Rows) != 2 {
+		t.Errorf("unexpected state: rows %v, err %v", model.Rows, model.StreamErr)
+	}
+}
+
+func TestEmptyLoadQuits(t *testing.T) {
+	batches := make(chan StreamBatch)
+	close(batches)
+
+	m := New(nil, 80, 24)
+	m.SetStream(batches, nil)
+	m.QuitOnEmptyInput = true
+	_, cmd := m.Update(waitForBatch(batches)())
+	if cmd == nil {
+		t.Fatal("expected a quit command for an empty input")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("expected a quit command for an empty input")
+	}
+
+	// A tab or live stream stays open to show that nothing arrived
+	m.QuitOnEmptyInput = false
+	if _, cmd = m.Update(waitForBatch(batches)()); cmd != nil {
+		t.Error("a live stream should not quit when it closes empty")
+	}
+}
+
+func TestCtrlCStopsLoading(t *testing.T) {
+	cancelled := false
+	batches := make(chan StreamBatch, 1)
+	m := New([][]string{{"NAME"}, {"web"}}, 80, 24)
+	m.SetStream(batches, func() { cancelled = true })
+
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	m = next.(Model)
+	if cmd != nil || !cancelled || m.StreamOpen || !m.LoadCancelled {
+		t.Fatalf("first Ctrl+C should only stop loading (cancelled=%v open=%v)", cancelled, m.StreamOpen)
+	}
+
+	// Batches still in flight are dropped
+	batches <- StreamBatch{Rows: [][]string{{"late"}}}
+	next, _ = m.Update(waitForBatch(batches)())
+	if len(next.(Model).Rows) != 2 {
+		t.Error("batch delivered after cancelling was appended")
+	}
+
+	// Once loading stopped, Ctrl+C quits
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd == nil {
+		t.Error("second Ctrl+C should quit")
+	}
+}
@@ -0,0 +1,160 @@
+package model
+
+import (
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Tab is one input shown in its own tab, with independent state
+type Tab struct {
+	Name  string
+	Model Model
+}
+
+// TabSet shows several inputs as tabs, switched with gt/gT or the number keys
+type TabSet struct {
+	Tabs     []Tab
+	Active   int
+	pendingG bool // 'g' was pressed, waiting for t or T
+	renderer func(TabSet) string
+}
+
+// tabMsg routes a message produced by a tab's command back to that tab
+type tabMsg struct {
+	tab int
+	msg tea.Msg
+}
+
+// teaPackage is the import path of Bubble Tea, whose own messages (quit, exec...) must reach the runtime untouched
+var teaPackage = reflect.TypeOf(tea.QuitMsg{}).PkgPath()
+
+// NewTabSet creates a tab set showing the first tab
+func NewTabSet(tabs []Tab) TabSet {
+	return TabSet{Tabs: tabs}
+}
+
+// SetRenderer sets the view renderer function
+func (t *TabSet) SetRenderer(renderer func(TabSet) string) {
+	t.renderer = renderer
+}
+
+// Current returns the model of the active tab
+func (t TabSet) Current() Model {
+	return t.Tabs[t.Active].Model
+}
+
+// Init starts every tab, e.g. loading its input
+func (t TabSet) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for i, tab := range t.Tabs {
+		cmds = append(cmds, tagCmd(i, tab.Model.Init()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// tagCmd wraps a tab's command so its result is delivered to that tab
+func tagCmd(tab int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			tagged := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				tagged[i] = tagCmd(tab, c)
+			}
+			return tagged
+		}
+		if msg == nil || reflect.TypeOf(msg).PkgPath() == teaPackage {
+			return msg
+		}
+		return tabMsg{tab: tab, msg: msg}
+	}
+}
+
+// Update handles tab switching and forwards everything else to the tabs
+func (t TabSet) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tabMsg:
+		return t.updateTab(msg.tab, msg.msg)
+	case tea.WindowSizeMsg:
+		// Every tab keeps its layout current; one line goes to the tab bar
+		var cmds []tea.Cmd
+		for i := range t.Tabs {
+			var cmd tea.Cmd
+			t, cmd = t.forward(i, tea.WindowSizeMsg{Width: msg.Width, Height: msg.Height - 1})
+			cmds = append(cmds, cmd)
+		}
+		return t, tea.Batch(cmds...)
+	case tea.KeyMsg:
+		if !t.Current().CapturesKeys() {
+			if t.pendingG {
+				return t.afterG(msg)
+			}
+			if handled := t.handleTabKey(msg.String()); handled {
+				return t, nil
+			}
+		}
+	}
+	return t.updateTab(t.Active, msg)
+}
+
+// handleTabKey starts gt/gT and switches directly with 1-9; a digit without its tab is left to the active tab
+func (t *TabSet) handleTabKey(key string) bool {
+	switch {
+	case key == "g":
+		t.pendingG = true
+		return true
+	case len(key) == 1 && key[0] >= '1' && key[0] <= '9':
+		idx := int(key[0] - '1')
+		if idx >= len(t.Tabs) {
+			return false
+		}
+		t.Active = idx
+		return true
+	}
+	return false
+}
+
+// afterG switches tabs on gt (next) and gT (previous); any other key reaches the active tab after the held back g
+func (t TabSet) afterG(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t.pendingG = false
+	switch msg.String() {
+	case "t":
+		t.Active = (t.Active + 1) % len(t.Tabs)
+		return t, nil
+	case "T":
+		t.Active = (t.Active + len(t.Tabs) - 1) % len(t.Tabs)
+		return t, nil
+	}
+	t, gCmd := t.forward(t.Active, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	next, cmd := t.updateTab(t.Active, msg)
+	return next, tea.Batch(gCmd, cmd)
+}
+
+// updateTab delivers a message to one tab
+func (t TabSet) updateTab(tab int, msg tea.Msg) (tea.Model, tea.Cmd) {
+	if tab < 0 || tab >= len(t.Tabs) {
+		return t, nil
+	}
+	return t.forward(tab, msg)
+}
+
+// forward updates a tab's model, tagging the resulting command with the tab
+func (t TabSet) forward(tab int, msg tea.Msg) (TabSet, tea.Cmd) {
+	tabs := append([]Tab(nil), t.Tabs...)
+	next, cmd := tabs[tab].Model.Update(msg)
+	tabs[tab].Model = next.(Model)
+	t.Tabs = tabs
+	return t, tagCmd(tab, cmd)
+}
+
+// View renders the tab bar and the active tab
+func (t TabSet) View() string {
+	if t.renderer != nil {
+		return t.renderer(t)
+	}
+	return t.Current().View()
+}
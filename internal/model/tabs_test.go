@@ -0,0 +1,140 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/action"
+)
+
+func newTestTabSet() TabSet {
+	return NewTabSet([]Tab{
+		{Name: "pods", Model: New([][]string{{"NAME"}, {"web"}}, 80, 23)},
+		{Name: "nodes", Model: New([][]string{{"NODE"}, {"a"}, {"b"}}, 80, 23)},
+		{Name: "svc", Model: New([][]string{{"SVC"}, {"api"}}, 80, 23)},
+	})
+}
+
+// press sends typed keys to a tab set
+func press(t TabSet, keys ...string) TabSet {
+	for _, key := range keys {
+		next, _ := t.Update(keyMsg(key))
+		t = next.(TabSet)
+	}
+	return t
+}
+
+func TestTabSwitching(t *testing.T) {
+	tabs := newTestTabSet()
+
+	if tabs = press(tabs, "g", "t"); tabs.Active != 1 {
+		t.Errorf("gt: Active = %d, want 1", tabs.Active)
+	}
+	if tabs = press(tabs, "g", "T", "g", "T"); tabs.Active != 2 {
+		t.Errorf("gT twice wraps around: Active = %d, want 2", tabs.Active)
+	}
+	if tabs = press(tabs, "1"); tabs.Active != 0 {
+		t.Errorf("1: Active = %d, want 0", tabs.Active)
+	}
+	if tabs = press(tabs, "9"); tabs.Active != 0 {
+		t.Errorf("9 with three tabs should do nothing, Active = %d", tabs.Active)
+	}
+}
+
+func TestTabKeysLeaveUnusedKeysToActions(t *testing.T) {
+	tabs := newTestTabSet()
+	for i := range tabs.Tabs {
+		tabs.Tabs[i].Model.Actions = []action.Action{
+			{Name: "nine", Key: "9", Command: "true", Confirm: true},
+			{Name: "gx", Key: "x", Command: "true", Confirm: true},
+		}
+	}
+
+	// 9 has no tab, so it runs the action bound to it
+	tabs = press(tabs, "9")
+	if p := tabs.Current().Prompt; p == nil || !strings.Contains(p.Label, "nine") {
+		t.Errorf("9 should run its action, prompt %+v", p)
+	}
+	tabs = press(tabs, "n")
+
+	// g followed by another key hands both to the tab
+	tabs = press(tabs, "g", "x")
+	if p := tabs.Current().Prompt; p == nil || !strings.Contains(p.Label, "gx") {
+		t.Errorf("x after g should reach the tab, prompt %+v", p)
+	}
+}
+
+func TestTabsKeepIndependentState(t *testing.T) {
+	tabs := newTestTabSet()
+	tabs = press(tabs, "2", "j", "s")
+
+	if tabs.Tabs[1].Model.ScrollOffset != 0 || !tabs.Tabs[1].Model.SelectedColumns[0] {
+		t.Errorf("keys did not reach the active tab: %+v", tabs.Tabs[1].Model.SelectedColumns)
+	}
+	if len(tabs.Tabs[0].Model.SelectedColumns) != 0 {
+		t.Error("selection leaked into another tab")
+	}
+}
+
+func TestFilterInputIsNotTabSwitching(t *testing.T) {
+	tabs := newTestTabSet()
+	tabs = press(tabs, "f", "g", "t", "2")
+
+	if tabs.Active != 0 {
+		t.Errorf("typing in the filter switched tabs to %d", tabs.Active)
+	}
+	if got := tabs.Current().FilterInput; got != "gt2" {
+		t.Errorf("FilterInput = %q, want %q", got, "gt2")
+	}
+}
+
+func TestTabMessagesReachTheirTab(t *testing.T) {
+	tabs := newTestTabSet()
+	batches := make(chan StreamBatch, 1)
+	tabs.Tabs[2].Model.SetStream(batches, nil)
+	batches <- StreamBatch{Rows: [][]string{{"db"}}}
+
+	// The command of tab 2 is tagged, so its result goes to tab 2 even while tab 0 is active
+	msg := tagCmd(2, waitForBatch(batches))()
+	next, _ := tabs.Update(msg)
+	tabs = next.(TabSet)
+
+	if len(tabs.Tabs[2].Model.Rows) != 3 || len(tabs.Tabs[0].Model.Rows) != 2 {
+		t.Errorf("batch went to the wrong tab: %v / %v", tabs.Tabs[0].Model.Rows, tabs.Tabs[2].Model.Rows)
+	}
+
+	// Bubble Tea's own messages are not wrapped
+	if _, ok := tagCmd(1, tea.Quit)().(tea.QuitMsg); !ok {
+		t.Error("quit message was wrapped")
+	}
+}
+
+func TestTabSetResizesEveryTab(t *testing.T) {
+	tabs := newTestTabSet()
+	next, _ := tabs.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	tabs = next.(TabSet)
+
+	for _, tab := range tabs.Tabs {
+		if tab.Model.TermWidth != 100 || tab.Model.TermHeight != 39 {
+			t.Errorf("%s: size %dx%d, want 100x39", tab.Name, tab.Model.TermWidth, tab.Model.TermHeight)
+		}
+	}
+}
+
+func TestPendingExportOfOtherTabs(t *testing.T) {
+	tabs := newTestTabSet()
+	tabs = press(tabs, "T")
+	next, _ := tabs.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("{{.NAME}}")})
+	tabs = next.(TabSet)
+	next, _ = tabs.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	tabs = press(next.(TabSet), "2", "q")
+
+	if got, err := tabs.Tabs[0].Model.PendingExport(); err != nil || got != "web" {
+		t.Errorf("template of the first tab gave %q, %v after quitting from the second", got, err)
+	}
+	if got, _ := tabs.Tabs[1].Model.PendingExport(); got != "" {
+		t.Errorf("second tab has nothing to print, got %q", got)
+	}
+}
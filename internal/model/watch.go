@@ -0,0 +1,178 @@
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Refresher produces a new snapshot of the table, e.g. by re-running a watched command
+// styled may be nil when the output has no ANSI escapes
+type Refresher func() (rows, styled [][]string, err error)
+
+// RefreshMsg carries the result of a refresh
+type RefreshMsg struct {
+	Rows       [][]string
+	StyledRows [][]string
+	Err        error
+	Time       time.Time
+}
+
+// watchTickMsg signals that the watch interval elapsed
+// gen identifies the tick so ticks scheduled before a pause or manual refresh can be ignored
+type watchTickMsg struct {
+	gen int
+}
+
+// SetRefresher enables watch mode: refresher runs every interval and its output replaces the rows
+// The current rows become the first snapshot of the history
+func (m *Model) SetRefresher(refresher Refresher, interval time.Duration) {
+	m.refresher = refresher
+	m.WatchInterval = interval
+	m.LastRefresh = time.Now()
+	m.recordSnapshot(m.Rows, m.StyledRows, m.LastRefresh)
+}
+
+// Watching reports whether the model refreshes its rows periodically
+func (m Model) Watching() bool {
+	return m.refresher != nil
+}
+
+// scheduleRefresh starts waiting for the next interval, invalidating any pending tick
+func (m *Model) scheduleRefresh() tea.Cmd {
+	m.watchGen++
+	return watchTick(m.WatchInterval, m.watchGen)
+}
+
+// watchTick waits for the interval and then emits a tick of generation gen
+func watchTick(interval time.Duration, gen int) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return watchTickMsg{gen: gen}
+	})
+}
+
+// refreshNow runs the refresher in the background, invalidating any pending tick
+func (m *Model) refreshNow() tea.Cmd {
+	if m.refresher == nil || m.refreshing {
+		return nil
+	}
+	m.watchGen++
+	m.refreshing = true
+	refresher := m.refresher
+	return func() tea.Msg {
+		rows, styled, err := refresher()
+		return RefreshMsg{Rows: rows, StyledRows: styled, Err: err, Time: time.Now()}
+	}
+}
+
+// handleWatchTick runs a refresh when the tick is current and watching is not paused
+func (m Model) handleWatchTick(msg watchTickMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != m.watchGen || m.WatchPaused {
+		return m, nil
+	}
+	return m, m.refreshNow()
+}
+
+// handleRefresh swaps in the new snapshot and schedules the next refresh
+func (m Model) handleRefresh(msg RefreshMsg) (tea.Model, tea.Cmd) {
+	m.refreshing = false
+	m.LastRefresh = msg.Time
+	m.RefreshErr = msg.Err
+
+	// On error keep showing the last good snapshot
+	if msg.Err == nil && len(msg.Rows) > 0 {
+		m.applySnapshot(msg.Rows, msg.StyledRows)
+		m.recordSnapshot(msg.Rows, msg.StyledRows, msg.Time)
+	}
+
+	if m.WatchPaused {
+		return m, nil
+	}
+	return m, m.scheduleRefresh()
+}
+
+// toggleWatchPause pauses or resumes periodic refreshes
+func (m *Model) toggleWatchPause() tea.Cmd {
+	m.WatchPaused = !m.WatchPaused
+	if m.WatchPaused {
+		// Invalidate the pending tick
+		m.watchGen++
+		return nil
+	}
+	if m.refreshing {
+		// The running refresh schedules the next tick when it completes
+		return nil
+	}
+	return m.scheduleRefresh()
+}
+
+// ReplaceRows swaps in a new snapshot of the table while keeping the interactive state:
+// the focused column, selected columns and filter follow their column by header name,
+// selected rows follow their row by key, and the scroll position is kept as far as the new data allows
+func (m *Model) ReplaceRows(rows, styled [][]string) {
+	var oldHeader []string
+	if len(m.Rows) > 0 {
+		oldHeader = m.Rows[0]
+	}
+	var newHeader []string
+	if len(rows) > 0 {
+		newHeader = rows[0]
+	}
+
+	// remap finds the new index of an old column by name
+	remap := func(oldIdx int) (int, bool) {
+		if oldIdx < 0 || oldIdx >= len(oldHeader) {
+			return -1, false
+		}
+		name := oldHeader[oldIdx]
+		for i, n := range newHeader {
+			if n == name {
+				return i, true
+			}
+		}
+		return -1, false
+	}
+
+	// Focused column: follow it by name, otherwise stay in bounds
+	if idx, ok := remap(m.CurrentColumn); ok {
+		m.CurrentColumn = idx
+	} else if m.CurrentColumn >= len(newHeader) {
+		m.CurrentColumn = max(len(newHeader)-1, 0)
+	}
+
+	// Selected columns follow their names; columns that disappeared are dropped
+	selected := make(map[int]bool)
+	for col := range m.SelectedColumns {
+		if idx, ok := remap(col); ok {
+			selected[idx] = true
+		}
+	}
+	m.SelectedColumns = selected
+	if m.ViewMode == ZoomView && len(m.SelectedColumns) == 0 {
+		m.ViewMode = NormalView
+	}
+
+	filterActive := len(m.FilteredRowIndices) > 0 || m.ViewMode == FilterView
+	filterColumn, filterColumnKept := remap(m.FilterColumnIndex)
+
+	m.remapSelectedRows(m.Rows, rows)
+	m.Rows = rows
+	m.StyledRows = styled
+
+	// Re-run the filter query against the new rows
+	if filterActive {
+		if filterColumnKept {
+			m.FilterColumnIndex = filterColumn
+			m.FilteredRowIndices = ApplyFuzzyFilter(m.Rows, m.FilterColumnIndex, m.FilterInput)
+		} else {
+			m.ClearFilter()
+			if m.ViewMode == FilterView {
+				m.ViewMode = NormalView
+			}
+		}
+	}
+
+	// Keep the scroll position unless the table got shorter
+	m.ScrollOffset = min(m.ScrollOffset, m.GetMaxScroll())
+	m.FilterScrollOffset = min(m.FilterScrollOffset, m.GetMaxFilterScroll())
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReplaceRowsKeepsStateByColumnName(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "STATUS", "AGE"},
+		{"web-1", "Running", "1d"},
+		{"web-2", "Pending", "2d"},
+		{"db", "Running", "3d"},
+	}
+	m := New(rows, 80, 24)
+	m.CurrentColumn = 1 // STATUS
+	m.SelectedColumns[2] = true
+	m.FilterColumnIndex = 1
+	m.FilterInput = "run"
+	m.FilteredRowIndices = ApplyFuzzyFilter(m.Rows, 1, "run")
+
+	// New snapshot with an extra column in front and reordered rows
+	newRows := [][]string{
+		{"NAMESPACE", "NAME", "STATUS", "AGE"},
+		{"default", "web-2", "Running", "2d"},
+		{"default", "db", "Running", "3d"},
+		{"default", "web-1", "Running", "1d"},
+		{"default", "cache", "Pending", "1m"},
+	}
+	m.ReplaceRows(newRows, nil)
+
+	if m.CurrentColumn != 2 {
+		t.Errorf("CurrentColumn = %d, want 2 (STATUS)", m.CurrentColumn)
+	}
+	if !m.SelectedColumns[3] || len(m.SelectedColumns) != 1 {
+		t.Errorf("SelectedColumns = %v, want only AGE (3)", m.SelectedColumns)
+	}
+	if m.FilterColumnIndex != 2 {
+		t.Errorf("FilterColumnIndex = %d, want 2", m.FilterColumnIndex)
+	}
+	// The filter query is re-applied to the new rows
+	if len(m.FilteredRowIndices) != 3 {
+		t.Errorf("Expected 3 filtered rows after refresh, got %d", len(m.FilteredRowIndices))
+	}
+}
+
+func TestReplaceRowsDropsMissingColumns(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "CPU"},
+		{"a", "1"},
+	}
+	m := New(rows, 80, 24)
+	m.CurrentColumn = 1
+	m.SelectedColumns[1] = true
+	m.ViewMode = ZoomView
+	m.FilterColumnIndex = 1
+	m.FilterInput = "1"
+	m.FilteredRowIndices = []int{1}
+
+	m.ReplaceRows([][]string{{"NAME"}, {"a"}}, nil)
+
+	if m.CurrentColumn != 0 {
+		t.Errorf("CurrentColumn = %d, want 0", m.CurrentColumn)
+	}
+	if len(m.SelectedColumns) != 0 {
+		t.Errorf("SelectedColumns should be empty, got %v", m.SelectedColumns)
+	}
+	if m.ViewMode != NormalView {
+		t.Errorf("Zoom without selected columns should fall back to normal view")
+	}
+	if len(m.FilteredRowIndices) != 0 || m.FilterInput != "" {
+		t.Errorf("Filter on a removed column should be cleared")
+	}
+}
+
+func TestReplaceRowsClampsScroll(t *testing.T) {
+	rows := [][]string{{"N"}}
+	for i := 0; i < 50; i++ {
+		rows = append(rows, []string{"x"})
+	}
+	m := New(rows, 80, 24)
+	m.ScrollOffset = 30
+
+	m.ReplaceRows(rows[:20], nil)
+
+	if m.ScrollOffset != m.GetMaxScroll() {
+		t.Errorf("ScrollOffset = %d, want clamped to %d", m.ScrollOffset, m.GetMaxScroll())
+	}
+}
+
+func TestRefreshErrorKeepsRows(t *testing.T) {
+	rows := [][]string{{"NAME"}, {"a"}}
+	m := New(rows, 80, 24)
+	m.SetRefresher(func() ([][]string, [][]string, error) { return nil, nil, nil }, time.Second)
+
+	updated, _ := m.Update(RefreshMsg{Err: errors.New("boom"), Time: time.Now()})
+	m = updated.(Model)
+
+	if m.RefreshErr == nil {
+		t.Error("Expected refresh error to be recorded")
+	}
+	if len(m.Rows) != 2 {
+		t.Errorf("Rows should be kept on refresh error, got %v", m.Rows)
+	}
+}
+
+func TestWatchFirstTickRefreshes(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"a"}}, 80, 24)
+	m.SetRefresher(func() ([][]string, [][]string, error) {
+		return [][]string{{"NAME"}, {"b"}}, nil, nil
+	}, time.Millisecond)
+
+	updated, cmd := m.Update(m.Init()())
+	if cmd == nil {
+		t.Fatal("The first tick should start a refresh")
+	}
+	updated, next := updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if m.Rows[1][0] != "b" {
+		t.Errorf("Expected refreshed rows, got %v", m.Rows)
+	}
+	if next == nil {
+		t.Error("The next refresh should be scheduled")
+	}
+}
+
+func TestWatchPauseIgnoresPendingTick(t *testing.T) {
+	calls := 0
+	m := New([][]string{{"NAME"}, {"a"}}, 80, 24)
+	m.SetRefresher(func() ([][]string, [][]string, error) {
+		calls++
+		return [][]string{{"NAME"}, {"b"}}, nil, nil
+	}, time.Millisecond)
+	tick := m.Init()()
+
+	m.toggleWatchPause()
+	if !m.WatchPaused {
+		t.Fatal("Expected watch to be paused")
+	}
+
+	_, cmd := m.Update(tick)
+	if cmd != nil {
+		t.Error("A tick scheduled before pausing should not trigger a refresh")
+	}
+
+	// Manual refresh still works while paused
+	cmd = m.refreshNow()
+	if cmd == nil {
+		t.Fatal("Expected a refresh command")
+	}
+	updated, next := m.Update(cmd())
+	m = updated.(Model)
+	if calls != 1 || m.Rows[1][0] != "b" {
+		t.Errorf("Expected manual refresh to swap rows, calls=%d rows=%v", calls, m.Rows)
+	}
+	if next != nil {
+		t.Error("No further refresh should be scheduled while paused")
+	}
+}
+
+func TestShiftPPausesWithoutPreview(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"a"}}, 80, 24)
+	m.SetRefresher(func() ([][]string, [][]string, error) { return nil, nil, nil }, time.Second)
+	if m = typeKeys(m, keyMsg("P")); !m.WatchPaused {
+		t.Error("P should pause watch mode without a preview")
+	}
+
+	m.PreviewCommand = "echo {NAME}"
+	if m = typeKeys(m, keyMsg("P")); !m.WatchPaused {
+		t.Error("P should toggle the preview, not resume, when a preview is set")
+	}
+}
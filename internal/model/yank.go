@@ -0,0 +1,132 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tablefy/internal/export"
+)
+
+// yankHint lists what the key after y copies
+const yankHint = "Copy: y cell | r row | c column | s selection"
+
+// startYank waits for the key telling what to copy
+func (m *Model) startYank() {
+	if len(m.Rows) == 0 {
+		return
+	}
+	m.pendingYank = true
+	m.StatusMessage = yankHint
+}
+
+// handleYank copies what the key after y names to the clipboard; any other key cancels
+func (m Model) handleYank(key string) (tea.Model, tea.Cmd) {
+	m.pendingYank = false
+	if m.clipboard == nil {
+		m.StatusMessage = "Copy failed: no clipboard available"
+		return m, nil
+	}
+
+	var text, what string
+	var ok bool
+	switch key {
+	case "y":
+		text, what, ok = m.yankCell()
+	case "r":
+		text, what, ok = m.yankRow()
+	case "c":
+		text, what, ok = m.yankColumn()
+	case "s":
+		text, what, ok = m.yankSelection()
+	default:
+		return m, nil
+	}
+	if !ok {
+		m.StatusMessage = what
+		return m, nil
+	}
+
+	return m, m.clipboard(text, func(err error) tea.Msg {
+		if err != nil {
+			return exportDoneMsg{status: fmt.Sprintf("Copy failed: %v", err)}
+		}
+		return exportDoneMsg{status: "Copied " + what}
+	})
+}
+
+// yankCell returns the focused cell of the current row
+func (m Model) yankCell() (text, what string, ok bool) {
+	rowIdx, found := m.CurrentRowIndex()
+	if !found || m.CurrentColumn >= len(m.Rows[0]) {
+		return "", "No cell to copy", false
+	}
+	text = cellAt(m.Rows[rowIdx], m.CurrentColumn)
+	return text, fmt.Sprintf("%s of row %d (%d characters)", m.Rows[0][m.CurrentColumn], m.CursorPosition()+1, len([]rune(text))), true
+}
+
+// yankRow returns the current row as a TSV line
+func (m Model) yankRow() (text, what string, ok bool) {
+	rowIdx, found := m.CurrentRowIndex()
+	if !found {
+		return "", "No row to copy", false
+	}
+	return export.TSVLine(m.Rows[rowIdx]), fmt.Sprintf("row %d (TSV)", m.CursorPosition()+1), true
+}
+
+// yankColumn returns the focused column's values in the rows shown, one per line
+func (m Model) yankColumn() (text, what string, ok bool) {
+	if m.CurrentColumn >= len(m.Rows[0]) {
+		return "", "No column to copy", false
+	}
+	var values []string
+	for _, rowIdx := range m.DataRowIndices() {
+		values = append(values, cellAt(m.Rows[rowIdx], m.CurrentColumn))
+	}
+	if len(values) == 0 {
+		return "", "No rows to copy", false
+	}
+	return strings.Join(values, "\n"), fmt.Sprintf("column %s (%d values)", m.Rows[0][m.CurrentColumn], len(values)), true
+}
+
+// yankSelection returns the selected rows, or every row shown, reduced to the selected columns, as TSV with the header
+func (m Model) yankSelection() (text, what string, ok bool) {
+	if len(m.SelectedRows) == 0 && len(m.SelectedColumns) == 0 {
+		return "", "Nothing selected: x selects rows, s columns", false
+	}
+
+	var rows []int
+	if len(m.SelectedRows) > 0 {
+		rows = m.TargetRows()
+	} else {
+		rows = m.DataRowIndices()
+	}
+	var cols []int
+	for col := range m.Rows[0] {
+		if len(m.SelectedColumns) == 0 || m.SelectedColumns[col] {
+			cols = append(cols, col)
+		}
+	}
+
+	pick := func(row []string) []string {
+		cells := make([]string, len(cols))
+		for i, col := range cols {
+			cells[i] = cellAt(row, col)
+		}
+		return cells
+	}
+	lines := []string{export.TSVLine(pick(m.Rows[0]))}
+	for _, rowIdx := range rows {
+		lines = append(lines, export.TSVLine(pick(m.Rows[rowIdx])))
+	}
+	return strings.Join(lines, "\n"), fmt.Sprintf("%d rows × %d columns (TSV with header)", len(rows), len(cols)), true
+}
+
+// cellAt returns the value in column col of the row, empty when the row is short
+func cellAt(row []string, col int) string {
+	if col < len(row) {
+		return row[col]
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeClipboard copies with copyText right away instead of writing to the terminal
+func fakeClipboard(copyText func(string) error) Clipboard {
+	return func(text string, done tea.ExecCallback) tea.Cmd {
+		return func() tea.Msg { return done(copyText(text)) }
+	}
+}
+
+func TestYank(t *testing.T) {
+	m := New([][]string{{"NAME", "STATUS", "NOTE"}, {"web", "Running", "a\tb"}, {"db", "Pending", ""}, {"cache", "Running", ""}}, 80, 24)
+	var copied string
+	m.SetClipboard(fakeClipboard(func(text string) error {
+		copied = text
+		return nil
+	}))
+	m.CurrentColumn = 1
+
+	tests := []struct {
+		keys   []string
+		copied string
+		status string
+	}{
+		{[]string{"y", "y"}, "Running", "Copied STATUS of row 1 (7 characters)"},
+		{[]string{"y", "r"}, "web\tRunning\ta b", "Copied row 1 (TSV)"},
+		{[]string{"y", "c"}, "Running\nPending\nRunning", "Copied column STATUS (3 values)"},
+		{[]string{"j", "x", "j", "x", "s", "y", "s"}, "STATUS\nPending\nRunning", "Copied 2 rows × 1 columns (TSV with header)"},
+	}
+	for _, tt := range tests {
+		copied = ""
+		for _, key := range tt.keys {
+			m = typeKeys(m, keyMsg(key))
+		}
+		if copied != tt.copied || m.StatusMessage != tt.status {
+			t.Errorf("%v copied %q with status %q, want %q and %q", tt.keys, copied, m.StatusMessage, tt.copied, tt.status)
+		}
+	}
+
+	// Any other key cancels, and goes no further
+	copied = ""
+	m = typeKeys(m, keyMsg("y"))
+	if m.StatusMessage != yankHint {
+		t.Errorf("y should list what can be copied, got %q", m.StatusMessage)
+	}
+	m = typeKeys(m, keyMsg("q"))
+	if copied != "" || m.pendingYank {
+		t.Errorf("q after y should cancel the copy, copied %q", copied)
+	}
+}
+
+func TestYankErrors(t *testing.T) {
+	m := New([][]string{{"NAME"}, {"web"}}, 80, 24)
+	if m = typeKeys(m, keyMsg("y"), keyMsg("y")); !strings.Contains(m.StatusMessage, "no clipboard") {
+		t.Errorf("status %q should report the missing clipboard", m.StatusMessage)
+	}
+
+	m.SetClipboard(fakeClipboard(func(string) error { return errors.New("no terminal") }))
+	if m = typeKeys(m, keyMsg("y"), keyMsg("s")); !strings.Contains(m.StatusMessage, "Nothing selected") {
+		t.Errorf("status %q should say nothing is selected", m.StatusMessage)
+	}
+	if m = typeKeys(m, keyMsg("y"), keyMsg("y")); m.StatusMessage != "Copy failed: no terminal" {
+		t.Errorf("status %q should report the clipboard error", m.StatusMessage)
+	}
+}
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ansiPattern matches terminal escape sequences: CSI (colors, cursor movement),
+// OSC (hyperlinks, titles) terminated by BEL or ST, and two-byte ESC sequences
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// ansiPrefixPattern matches an escape sequence at the start of a string
+var ansiPrefixPattern = regexp.MustCompile(`^(?:` + ansiPattern.String() + `)`)
+
+// ansiReset restores default attributes after a styled cell
+const ansiReset = "\x1b[0m"
+
+// HasANSI reports whether s contains escape sequences
+func HasANSI(s string) bool {
+	return strings.Contains(s, "\x1b")
+}
+
+// EscapeLength returns the length of the escape sequence at the start of s, or 0 when s doesn't start with one
+func EscapeLength(s string) int {
+	if loc := ansiPrefixPattern.FindStringIndex(s); loc != nil {
+		return loc[1]
+	}
+	return 0
+}
+
+// StripANSI removes all escape sequences from s
+func StripANSI(s string) string {
+	if !HasANSI(s) {
+		return s
+	}
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// styledLine is a line split into its visible runes, remembering where each rune sits in the raw line
+type styledLine struct {
+	raw     string
+	visible []rune
+	// offsets[i] is the raw byte offset where visible rune i and the escapes preceding it begin
+	// offsets[len(visible)] is len(raw)
+	offsets []int
+}
+
+// newStyledLine indexes the visible runes of a raw line containing escape sequences
+func newStyledLine(raw string) styledLine {
+	line := styledLine{raw: raw}
+	escapes := ansiPattern.FindAllStringIndex(raw, -1)
+
+	pos := 0
+	for pos < len(raw) {
+		start := pos
+		// Escapes in front of a rune travel with it, so a color set at the start of a column stays in that column
+		for len(escapes) > 0 && escapes[0][0] == pos {
+			pos = escapes[0][1]
+			escapes = escapes[1:]
+		}
+		if pos >= len(raw) {
+			break
+		}
+		r, size := utf8.DecodeRuneInString(raw[pos:])
+		line.visible = append(line.visible, r)
+		line.offsets = append(line.offsets, start)
+		pos += size
+	}
+	line.offsets = append(line.offsets, len(raw))
+
+	return line
+}
+
+// segment returns the raw text (escapes included) of visible runes [start, end)
+func (l styledLine) segment(start, end int) string {
+	return l.raw[l.offsets[start]:l.offsets[end]]
+}
+
+// trimStyled trims leading and trailing whitespace from a styled cell while keeping every escape sequence,
+// and appends a reset so the cell's colors never bleed into borders or neighbouring cells
+func trimStyled(s string) string {
+	if !HasANSI(s) {
+		return strings.TrimSpace(s)
+	}
+
+	// Tokenize into escapes and visible text
+	var tokens []string
+	var isEscape []bool
+	last := 0
+	for _, loc := range ansiPattern.FindAllStringIndex(s, -1) {
+		for _, r := range s[last:loc[0]] {
+			tokens = append(tokens, string(r))
+			isEscape = append(isEscape, false)
+		}
+		tokens = append(tokens, s[loc[0]:loc[1]])
+		isEscape = append(isEscape, true)
+		last = loc[1]
+	}
+	for _, r := range s[last:] {
+		tokens = append(tokens, string(r))
+		isEscape = append(isEscape, false)
+	}
+
+	// Find the first and last visible non-space tokens
+	first, lastVisible := -1, -1
+	for i, tok := range tokens {
+		if !isEscape[i] && strings.TrimSpace(tok) != "" {
+			if first < 0 {
+				first = i
+			}
+			lastVisible = i
+		}
+	}
+	if first < 0 {
+		// Nothing visible: an empty cell stays empty
+		return ""
+	}
+
+	var b strings.Builder
+	for i, tok := range tokens {
+		if isEscape[i] || (i >= first && i <= lastVisible) {
+			b.WriteString(tok)
+		}
+	}
+	b.WriteString(ansiReset)
+	return b.String()
+}
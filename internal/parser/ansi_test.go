@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"\x1b[32mRunning\x1b[0m", "Running"},
+		{"\x1b[1;31mError\x1b[m", "Error"},
+		{"\x1b]8;;https://example.com\x07link\x1b]8;;\x07", "link"},
+		{"plain", "plain"},
+	}
+
+	for _, tt := range tests {
+		if got := StripANSI(tt.input); got != tt.expected {
+			t.Errorf("StripANSI(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseTableStyledSpaceSeparated(t *testing.T) {
+	input := "NAME     STATUS     AGE\n" +
+		"web-1    \x1b[32mRunning\x1b[0m    3d\n" +
+		"\x1b[1mdb\x1b[0m       \x1b[31mError\x1b[0m      5m\n"
+
+	rows, styled := ParseTableStyled(input)
+
+	expected := [][]string{
+		{"NAME", "STATUS", "AGE"},
+		{"web-1", "Running", "3d"},
+		{"db", "Error", "5m"},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("Expected %d rows, got %d", len(expected), len(rows))
+	}
+	for i, row := range expected {
+		for j, value := range row {
+			if rows[i][j] != value {
+				t.Errorf("Row %d, column %d: expected %q, got %q", i, j, value, rows[i][j])
+			}
+		}
+	}
+
+	if len(styled) != len(rows) {
+		t.Fatalf("Expected %d styled rows, got %d", len(rows), len(styled))
+	}
+	if styled[1][1] != "\x1b[32mRunning\x1b[0m"+ansiReset {
+		t.Errorf("Styled STATUS = %q, want the green escape kept", styled[1][1])
+	}
+	if styled[2][0] != "\x1b[1mdb\x1b[0m"+ansiReset {
+		t.Errorf("Styled NAME = %q, want the bold escape kept", styled[2][0])
+	}
+	// Cells without escapes are plain
+	if styled[1][2] != "3d" {
+		t.Errorf("Styled AGE = %q, want %q", styled[1][2], "3d")
+	}
+	// Stripping a styled cell gives back the plain cell
+	for i := range rows {
+		for j := range rows[i] {
+			if StripANSI(styled[i][j]) != rows[i][j] {
+				t.Errorf("Cell %d,%d: stripped styled %q != plain %q", i, j, StripANSI(styled[i][j]), rows[i][j])
+			}
+		}
+	}
+}
+
+func TestParseTableStyledTabSeparated(t *testing.T) {
+	input := "NAME\tSTATUS\nweb\t\x1b[33mPending\x1b[0m\n"
+
+	rows, styled := ParseTableStyled(input)
+
+	if rows[1][1] != "Pending" {
+		t.Errorf("Expected plain Pending, got %q", rows[1][1])
+	}
+	if !strings.Contains(styled[1][1], "\x1b[33m") {
+		t.Errorf("Expected yellow escape in styled cell, got %q", styled[1][1])
+	}
+}
+
+func TestParseTableStyledWithoutEscapes(t *testing.T) {
+	rows, styled := ParseTableStyled("NAME  AGE\nbob   3\n")
+
+	if len(rows) != 2 {
+		t.Errorf("Expected 2 rows, got %d", len(rows))
+	}
+	if styled != nil {
+		t.Errorf("Expected nil styled rows for plain input, got %v", styled)
+	}
+}
+
+// TestParseTableStripsEscapesBeforeParsing verifies escapes don't shift column positions
+func TestParseTableStripsEscapesBeforeParsing(t *testing.T) {
+	input := "\x1b[1mNAME\x1b[0m     \x1b[1mSTATUS\x1b[0m\n" +
+		"\x1b[34mapi\x1b[0m      ok\n"
+
+	rows := ParseTable(input)
+
+	if rows[0][0] != "NAME" || rows[0][1] != "STATUS" {
+		t.Errorf("Unexpected header %q", rows[0])
+	}
+	if rows[1][0] != "api" || rows[1][1] != "ok" {
+		t.Errorf("Unexpected data row %q", rows[1])
+	}
+}
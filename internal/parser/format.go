@@ -0,0 +1,309 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Format is an input format the parser understands
+type Format int
+
+const (
+	FormatAuto Format = iota // Detect from the first non-blank line
+	FormatText               // Columns aligned with spaces, or separated by tabs (kubectl, helm, ps...)
+	FormatCSV                // Comma-separated values with a header line
+	FormatJSON               // JSON array of objects, or a sequence of objects (NDJSON)
+)
+
+// formatNames maps formats to the names used in flags and messages
+var formatNames = map[Format]string{
+	FormatAuto: "auto",
+	FormatText: "text",
+	FormatCSV:  "csv",
+	FormatJSON: "json",
+}
+
+// String returns the format name
+func (f Format) String() string {
+	return formatNames[f]
+}
+
+// multiSpacePattern matches the column separators of aligned text
+var multiSpacePattern = regexp.MustCompile(`\s{2,}`)
+
+// ParseFormat converts a format name, as used on the command line, to a Format
+func ParseFormat(name string) (Format, error) {
+	for f, n := range formatNames {
+		if strings.EqualFold(n, name) {
+			return f, nil
+		}
+	}
+	return FormatText, fmt.Errorf("unknown input format %q (available: auto, text, csv, json)", name)
+}
+
+// FormatForFile picks the format from a file name's extension; unknown extensions are read as aligned text
+// A compression extension is skipped, so pods.csv.gz is CSV
+func FormatForFile(name string) Format {
+	name = strings.ToLower(name)
+	switch filepath.Ext(name) {
+	case ".gz", ".zst", ".bz2":
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+
+	switch filepath.Ext(name) {
+	case ".csv":
+		return FormatCSV
+	case ".json", ".jsonl", ".ndjson":
+		return FormatJSON
+	}
+	return FormatText
+}
+
+// DetectFormat guesses the format from the first non-blank line of the input
+// A line starting with { or [ is JSON; a comma-separated line without tabs or
+// space-aligned columns is CSV; anything else is aligned text
+func DetectFormat(line string) Format {
+	trimmed := strings.TrimSpace(StripANSI(line))
+	switch {
+	case strings.HasPrefix(trimmed, "{"), strings.HasPrefix(trimmed, "["):
+		return FormatJSON
+	case strings.Contains(trimmed, ",") && !strings.Contains(trimmed, "\t") && !multiSpacePattern.MatchString(trimmed):
+		return FormatCSV
+	}
+	return FormatText
+}
+
+// LineParser turns the lines of an input into table rows
+type LineParser interface {
+	// Line parses the next line; ok is false when it produced no row. The first row is the header.
+	Line(raw string) (row, styled []string, ok bool)
+	// Flush returns the rows that can only be built once the whole input was read (JSON documents)
+	Flush() [][]string
+}
+
+// NewLineParser creates a line parser for the format
+func NewLineParser(format Format) LineParser {
+	switch format {
+	case FormatText:
+		return NewStream()
+	case FormatCSV:
+		return &csvStream{}
+	case FormatJSON:
+		return &jsonStream{}
+	}
+	return &autoStream{}
+}
+
+// Parse parses a complete input in the given format
+// styled is nil when the input has no ANSI escapes
+func Parse(input string, format Format) (rows [][]string, styled [][]string) {
+	p := NewLineParser(format)
+	for _, line := range strings.Split(input, "\n") {
+		row, styledRow, ok := p.Line(line)
+		if !ok {
+			continue
+		}
+		rows = append(rows, row)
+		styled = append(styled, styledRow)
+	}
+	for _, row := range p.Flush() {
+		rows = append(rows, row)
+		styled = append(styled, row)
+	}
+
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, nil
+	}
+	if !HasANSI(input) {
+		return rows, nil
+	}
+	return rows, styled
+}
+
+// Flush returns nothing: aligned text produces every row as its line arrives
+func (s *Stream) Flush() [][]string {
+	return nil
+}
+
+// autoStream picks the format from the first non-blank line and parses everything with it
+type autoStream struct {
+	parser LineParser
+}
+
+func (s *autoStream) Line(raw string) ([]string, []string, bool) {
+	if s.parser == nil {
+		if strings.TrimSpace(StripANSI(raw)) == "" {
+			return nil, nil, false
+		}
+		s.parser = NewLineParser(DetectFormat(raw))
+	}
+	return s.parser.Line(raw)
+}
+
+func (s *autoStream) Flush() [][]string {
+	if s.parser == nil {
+		return nil
+	}
+	return s.parser.Flush()
+}
+
+// csvStream parses one CSV record per line; data rows are padded to the header's width
+// Quoted fields spanning several lines are not supported
+type csvStream struct {
+	width int
+}
+
+func (s *csvStream) Line(raw string) ([]string, []string, bool) {
+	plain := StripANSI(raw)
+	if strings.TrimSpace(plain) == "" {
+		return nil, nil, false
+	}
+
+	reader := csv.NewReader(strings.NewReader(plain))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	row, err := reader.Read()
+	if err != nil {
+		// Keep the malformed record visible rather than dropping it
+		row = []string{plain}
+	}
+
+	if s.width == 0 {
+		s.width = len(row)
+	}
+	for len(row) < s.width {
+		row = append(row, "")
+	}
+	return row, row, true
+}
+
+func (s *csvStream) Flush() [][]string {
+	return nil
+}
+
+// jsonStream collects the whole document: JSON can only be turned into rows once it is complete
+type jsonStream struct {
+	input strings.Builder
+}
+
+func (s *jsonStream) Line(raw string) ([]string, []string, bool) {
+	s.input.WriteString(StripANSI(raw))
+	s.input.WriteString("\n")
+	return nil, nil, false
+}
+
+func (s *jsonStream) Flush() [][]string {
+	input := s.input.String()
+	rows, err := parseJSON(input)
+	if err != nil && len(rows) == 0 {
+		// Not JSON after all: show it as text rather than nothing
+		rows, _ = Parse(input, FormatText)
+	}
+	return rows
+}
+
+// parseJSON turns an array of objects, or a sequence of objects, into rows
+// The header is every key in order of first appearance; nested values are shown as compact JSON
+// and non-object values go to a "value" column. On a syntax error the rows decoded so far are returned.
+func parseJSON(input string) ([][]string, error) {
+	var values []json.RawMessage
+	trimmed := strings.TrimSpace(input)
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &values); err != nil {
+			return nil, err
+		}
+	} else {
+		dec := json.NewDecoder(strings.NewReader(trimmed))
+		for dec.More() {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return jsonRows(values), err
+			}
+			values = append(values, value)
+		}
+	}
+	return jsonRows(values), nil
+}
+
+// jsonRows builds the table from decoded values
+func jsonRows(values []json.RawMessage) [][]string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	var header []string
+	columns := make(map[string]int)
+	var records []map[string]string
+	for _, value := range values {
+		keys, cells, ok := objectFields(value)
+		if !ok {
+			keys = []string{"value"}
+			cells = map[string]string{"value": jsonCell(value)}
+		}
+		for _, key := range keys {
+			if _, seen := columns[key]; !seen {
+				columns[key] = len(header)
+				header = append(header, key)
+			}
+		}
+		records = append(records, cells)
+	}
+
+	rows := [][]string{header}
+	for _, record := range records {
+		row := make([]string, len(header))
+		for key, cell := range record {
+			row[columns[key]] = cell
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// objectFields returns the keys of a JSON object in document order and its values as cells
+func objectFields(raw json.RawMessage) (keys []string, cells map[string]string, ok bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, false
+	}
+
+	cells = make(map[string]string)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, false
+		}
+		key := fmt.Sprint(tok)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, false
+		}
+		if _, dup := cells[key]; !dup {
+			keys = append(keys, key)
+		}
+		cells[key] = jsonCell(value)
+	}
+	return keys, cells, true
+}
+
+// jsonCell formats a JSON value for a cell: strings unquoted, null empty, everything else compact JSON
+func jsonCell(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	if string(bytes.TrimSpace(raw)) == "null" {
+		return ""
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return string(raw)
+	}
+	return compact.String()
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		line string
+		want Format
+	}{
+		{"NAME      READY   STATUS", FormatText},
+		{"NAME\tNAMESPACE", FormatText},
+		{"name,namespace,status", FormatCSV},
+		{"  [", FormatJSON},
+		{`{"name": "web"}`, FormatJSON},
+		{"NAME", FormatText},
+		{"Hello, world  again", FormatText},
+	}
+
+	for _, tt := range tests {
+		if got := DetectFormat(tt.line); got != tt.want {
+			t.Errorf("DetectFormat(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestFormatForFile(t *testing.T) {
+	tests := map[string]Format{
+		"nodes.csv":       FormatCSV,
+		"services.JSON":   FormatJSON,
+		"events.ndjson":   FormatJSON,
+		"pods.txt":        FormatText,
+		"pods":            FormatText,
+		"/tmp/dump.data":  FormatText,
+		"nodes.csv.gz":    FormatCSV,
+		"events.json.zst": FormatJSON,
+		"archive.gz":      FormatText,
+	}
+	for name, want := range tests {
+		if got := FormatForFile(name); got != want {
+			t.Errorf("FormatForFile(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"auto": FormatAuto, "text": FormatText, "CSV": FormatCSV, "json": FormatJSON} {
+		if got, err := ParseFormat(name); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml) should fail")
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	input := "name, status,restarts\nweb,Running,0\n\"db, primary\",Pending\n"
+	rows, _ := Parse(input, FormatAuto)
+
+	want := [][]string{
+		{"name", "status", "restarts"},
+		{"web", "Running", "0"},
+		{"db, primary", "Pending", ""},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Parse() = %q, want %q", rows, want)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	input := `[
+  {"name": "web", "replicas": 3, "labels": {"app": "web"}},
+  {"name": "db", "ready": true, "replicas": null}
+]`
+	rows, _ := Parse(input, FormatAuto)
+
+	want := [][]string{
+		{"name", "replicas", "labels", "ready"},
+		{"web", "3", `{"app":"web"}`, ""},
+		{"db", "", "", "true"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Parse() = %q, want %q", rows, want)
+	}
+}
+
+func TestParseNDJSON(t *testing.T) {
+	input := "{\"level\":\"info\",\"msg\":\"started\"}\n{\"level\":\"error\",\"msg\":\"failed\",\"code\":7}\n"
+	rows, _ := Parse(input, FormatJSON)
+
+	want := [][]string{
+		{"level", "msg", "code"},
+		{"info", "started", ""},
+		{"error", "failed", "7"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Parse() = %q, want %q", rows, want)
+	}
+}
+
+func TestParseInvalidJSONFallsBackToText(t *testing.T) {
+	rows, _ := Parse("[not json]  really\nrow  two\n", FormatJSON)
+	if len(rows) != 2 || rows[0][0] != "[not json]" {
+		t.Errorf("expected a text table, got %q", rows)
+	}
+}
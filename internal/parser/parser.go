@@ -3,11 +3,12 @@ package parser
 import (
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 // ColumnPosition represents a column with its starting position and name
 type ColumnPosition struct {
-	Start int    // Starting position of the column in the line
+	Start int    // Starting position of the column in the line, in runes
 	Name  string // Column name from the header
 }
 
@@ -51,7 +52,8 @@ func extractColumnPositions(headerLine string) []ColumnPosition {
 	var columnStarts []int
 	columnStarts = append(columnStarts, 0)
 	for _, match := range matches {
-		columnStarts = append(columnStarts, match[1])
+		// Count runes rather than bytes so multi-byte header names don't shift later columns
+		columnStarts = append(columnStarts, utf8.RuneCountInString(headerLine[:match[1]]))
 	}
 
 	// Create ColumnPosition objects with the column starts and names
@@ -70,8 +72,11 @@ func extractColumnPositions(headerLine string) []ColumnPosition {
 
 // extractValuesByPosition extracts column values from a data line using column positions
 // Each value starts at the column's start position and extends until the next column starts
+// Positions are rune offsets (command output aligned with text/tabwriter pads by runes),
+// so slicing never cuts a multi-byte UTF-8 sequence in half
 func extractValuesByPosition(line string, positions []ColumnPosition) []string {
 	var result []string
+	runes := []rune(line)
 
 	for i, pos := range positions {
 		var value string
@@ -81,21 +86,21 @@ func extractValuesByPosition(line string, positions []ColumnPosition) []string {
 		if i+1 < len(positions) {
 			endPos = positions[i+1].Start
 		} else {
-			endPos = len(line)
+			endPos = len(runes)
 		}
 
 		// Make sure we don't go past the line length
 		start := pos.Start
-		if start > len(line) {
-			start = len(line)
+		if start > len(runes) {
+			start = len(runes)
 		}
-		if endPos > len(line) {
-			endPos = len(line)
+		if endPos > len(runes) {
+			endPos = len(runes)
 		}
 
 		// Extract substring and trim whitespace
-		if start < len(line) && endPos > start {
-			value = strings.TrimSpace(line[start:endPos])
+		if start < len(runes) && endPos > start {
+			value = strings.TrimSpace(string(runes[start:endPos]))
 		}
 
 		result = append(result, value)
@@ -104,66 +109,41 @@ func extractValuesByPosition(line string, positions []ColumnPosition) []string {
 	return result
 }
 
-// ParseTable parses the input and converts it to rows and columns
-// It uses column positions from the header to align data rows correctly when space-separated
-// For tab-separated data, it uses simple tab splitting
-func ParseTable(input string) [][]string {
-	lines := strings.Split(input, "\n")
-	var validLines []string
+// extractStyledValuesByPosition is extractValuesByPosition for a line containing escape sequences
+// Positions refer to visible runes; each value keeps the escapes found inside its column
+func extractStyledValuesByPosition(line styledLine, positions []ColumnPosition) []string {
+	var result []string
+	n := len(line.visible)
 
-	// Filter empty lines
-	for _, line := range lines {
-		if strings.TrimSpace(line) != "" {
-			validLines = append(validLines, line)
+	for i, pos := range positions {
+		endPos := n
+		if i+1 < len(positions) {
+			endPos = min(positions[i+1].Start, n)
 		}
-	}
+		start := min(pos.Start, n)
 
-	if len(validLines) == 0 {
-		return nil
-	}
-
-	// Check if the data is tab-separated (like helm output)
-	header := validLines[0]
-	isTabSeparated := strings.Contains(header, "\t")
-
-	if isTabSeparated {
-		// For tab-separated data, use simple splitting
-		var rows [][]string
-		for _, line := range validLines {
-			fields := strings.Split(line, "\t")
-			rows = append(rows, fields)
+		var value string
+		if start < n && endPos > start {
+			value = trimStyled(line.segment(start, endPos))
 		}
-		return rows
-	}
-
-	// For space-separated data, use position-based alignment
-	columnPositions := extractColumnPositions(header)
-
-	if len(columnPositions) == 0 {
-		return nil
-	}
-
-	// Extract header row
-	var rows [][]string
-	headerRow := make([]string, len(columnPositions))
-	for i, pos := range columnPositions {
-		headerRow[i] = pos.Name
+		result = append(result, value)
 	}
-	rows = append(rows, headerRow)
 
-	// Process each data line using column positions
-	for i := 1; i < len(validLines); i++ {
-		row := extractValuesByPosition(validLines[i], columnPositions)
-
-		// Ensure row has the correct number of columns (fill missing ones with empty strings)
-		if len(row) < len(columnPositions) {
-			for len(row) < len(columnPositions) {
-				row = append(row, "")
-			}
-		}
-
-		rows = append(rows, row)
-	}
+	return result
+}
 
+// ParseTable parses the input and converts it to rows and columns
+// It uses column positions from the header to align data rows correctly when space-separated
+// For tab-separated data, it uses simple tab splitting
+// ANSI escape sequences are stripped before parsing
+func ParseTable(input string) [][]string {
+	rows, _ := ParseTableStyled(input)
 	return rows
 }
+
+// ParseTableStyled parses the input like ParseTable and also returns the cells with their
+// original ANSI escape sequences (colors, hyperlinks) preserved
+// Rows are always parsed and measured on the stripped text; styled is nil when the input has no escapes
+func ParseTableStyled(input string) (rows [][]string, styled [][]string) {
+	return Parse(input, FormatText)
+}
@@ -228,3 +228,29 @@ func TestSplitByMultipleSpaces(t *testing.T) {
 		}
 	}
 }
+
+func TestParseTableMultiByteCharacters(t *testing.T) {
+	// Columns aligned by rune count, as produced by text/tabwriter (kubectl, docker, helm)
+	input := `NAME    STATUS   AGE
+café    Running  3d
+日本語     Pending  5m`
+
+	rows := ParseTable(input)
+
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(rows))
+	}
+
+	expected := [][]string{
+		{"NAME", "STATUS", "AGE"},
+		{"café", "Running", "3d"},
+		{"日本語", "Pending", "5m"},
+	}
+	for i, row := range expected {
+		for j, value := range row {
+			if rows[i][j] != value {
+				t.Errorf("Row %d, column %d: expected %q, got %q", i, j, value, rows[i][j])
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package parser
+
+import "strings"
+
+// Stream parses a table one line at a time, for input that is still being written
+// The first non-blank line is the header; every later line is parsed against it,
+// the same way ParseTableStyled parses a complete input
+type Stream struct {
+	started   bool
+	tabs      bool
+	positions []ColumnPosition
+}
+
+// NewStream creates a parser waiting for the header line
+func NewStream() *Stream {
+	return &Stream{}
+}
+
+// Line parses the next line of input
+// ok is false for blank lines, which are skipped. The first row returned is the header.
+// styled holds the cells with their ANSI escapes; it is row itself when the line has none.
+func (s *Stream) Line(raw string) (row, styled []string, ok bool) {
+	plain := StripANSI(raw)
+	if strings.TrimSpace(plain) == "" {
+		return nil, nil, false
+	}
+	hasStyles := HasANSI(raw)
+
+	if !s.started {
+		s.started = true
+		s.tabs = strings.Contains(plain, "\t")
+		if !s.tabs {
+			s.positions = extractColumnPositions(plain)
+			row = make([]string, len(s.positions))
+			for i, pos := range s.positions {
+				row[i] = pos.Name
+			}
+			if !hasStyles {
+				return row, row, true
+			}
+			return row, extractStyledValuesByPosition(newStyledLine(raw), s.positions), true
+		}
+	}
+
+	// Tab-separated data uses simple splitting
+	if s.tabs {
+		row = strings.Split(plain, "\t")
+		if !hasStyles {
+			return row, row, true
+		}
+		styled = strings.Split(raw, "\t")
+		for j, field := range styled {
+			if HasANSI(field) {
+				styled[j] = field + ansiReset
+			}
+		}
+		return row, styled, true
+	}
+
+	// Space-separated data uses the header's column positions;
+	// missing trailing columns are filled with empty strings
+	row = extractValuesByPosition(plain, s.positions)
+	for len(row) < len(s.positions) {
+		row = append(row, "")
+	}
+	if !hasStyles {
+		return row, row, true
+	}
+	return row, extractStyledValuesByPosition(newStyledLine(raw), s.positions), true
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStreamMatchesParseTable(t *testing.T) {
+	lines := []string{
+		"",
+		"NAME      STATUS    AGE",
+		"web-1     Running   1d",
+		"",
+		"db        Pending",
+		"cache     \x1b[32mRunning\x1b[0m   3h",
+	}
+
+	stream := NewStream()
+	var rows [][]string
+	for _, line := range lines {
+		if row, _, ok := stream.Line(line); ok {
+			rows = append(rows, row)
+		}
+	}
+
+	input := ""
+	for _, line := range lines {
+		input += line + "\n"
+	}
+	if want := ParseTable(input); !reflect.DeepEqual(rows, want) {
+		t.Errorf("stream rows = %q, want %q", rows, want)
+	}
+}
+
+func TestStreamStyledCells(t *testing.T) {
+	stream := NewStream()
+	stream.Line("NAME\tSTATUS")
+
+	row, styled, ok := stream.Line("web\t\x1b[31mFailed\x1b[0m")
+	if !ok || row[1] != "Failed" {
+		t.Fatalf("unexpected row %q", row)
+	}
+	if styled[1] != "\x1b[31mFailed\x1b[0m"+ansiReset {
+		t.Errorf("styled cell = %q", styled[1])
+	}
+
+	// Without escapes the styled cells are the plain row
+	row, styled, _ = stream.Line("db\tRunning")
+	if !reflect.DeepEqual(row, styled) {
+		t.Errorf("styled = %q, want %q", styled, row)
+	}
+}
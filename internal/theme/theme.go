@@ -0,0 +1,278 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme holds the colors used across all views
+// Colors accept anything lipgloss.Color understands ("#9D4EDD", "252", "5")
+// An empty color means "no color": the monochrome preset relies on text attributes instead
+type Theme struct {
+	Name            string `json:"name"`
+	Border          string `json:"border"`           // Table borders
+	Header          string `json:"header"`           // Header row text
+	Text            string `json:"text"`             // Cell text
+	Focus           string `json:"focus"`            // Background of the focused column
+	Selection       string `json:"selection"`        // Background of selected columns
+	FilterHighlight string `json:"filter_highlight"` // Background of the column being filtered
+	FilterPrompt    string `json:"filter_prompt"`    // Filter input line
+	Indicator       string `json:"indicator"`        // Filter badge shown above the table
+	Help            string `json:"help"`             // Help and status line
+	Title           string `json:"title"`            // Zoom view title
+	Added           string `json:"added"`            // Rows that appeared since the previous refresh
+	Changed         string `json:"changed"`          // Background of cells that changed since the previous refresh
+	Removed         string `json:"removed"`          // Rows that disappeared, shown struck-through
+}
+
+// presets holds the built-in themes by name
+var presets = map[string]Theme{
+	"dark": {
+		Name:            "dark",
+		Border:          "#9D4EDD",
+		Header:          "252",
+		Text:            "252",
+		Focus:           "#3D3D3D",
+		Selection:       "#5A4E8C",
+		FilterHighlight: "#8B7BA8",
+		FilterPrompt:    "220",
+		Indicator:       "11",
+		Help:            "241",
+		Title:           "#9D4EDD",
+		Added:           "#57CC99",
+		Changed:         "#7A5C00",
+		Removed:         "243",
+	},
+	"light": {
+		Name:            "light",
+		Border:          "#6A1B9A",
+		Header:          "232",
+		Text:            "235",
+		Focus:           "#C5D5F5",
+		Selection:       "#DCC8F2",
+		FilterHighlight: "#F3DFA2",
+		FilterPrompt:    "#9A4F00",
+		Indicator:       "#9A4F00",
+		Help:            "243",
+		Title:           "#6A1B9A",
+		Added:           "#1B7F3B",
+		Changed:         "#FFE8A3",
+		Removed:         "248",
+	},
+	"high-contrast": {
+		Name:            "high-contrast",
+		Border:          "15",
+		Header:          "15",
+		Text:            "15",
+		Focus:           "4",
+		Selection:       "5",
+		FilterHighlight: "3",
+		FilterPrompt:    "11",
+		Indicator:       "11",
+		Help:            "15",
+		Title:           "14",
+		Added:           "10",
+		Changed:         "3",
+		Removed:         "9",
+	},
+	"monochrome": {
+		Name: "monochrome",
+	},
+}
+
+// Default returns the dark theme, which matches the original tablefy colors
+func Default() Theme {
+	return presets["dark"]
+}
+
+// Names returns the names of the built-in themes in alphabetical order
+func Names() []string {
+	var names []string
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the built-in theme with the given name
+func Get(name string) (Theme, error) {
+	t, ok := presets[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (available: %v)", name, Names())
+	}
+	return t, nil
+}
+
+// Load resolves the theme to use
+// Precedence: an explicit name, then NO_COLOR (monochrome), then the dark default.
+// A theme file, if given or present in the user config directory, overrides individual colors.
+// Its optional "base" key selects the preset it starts from.
+// As user configuration, a theme file also overrides NO_COLOR.
+func Load(name, file string) (Theme, error) {
+	if file == "" {
+		file = defaultFile()
+		if _, err := os.Stat(file); err != nil {
+			file = ""
+		}
+	}
+
+	t := Default()
+	switch {
+	case name != "":
+		preset, err := Get(name)
+		if err != nil {
+			return Theme{}, err
+		}
+		t = preset
+	case file == "" && os.Getenv("NO_COLOR") != "":
+		// https://no-color.org: never add color when NO_COLOR is set, unless configured explicitly
+		return presets["monochrome"], nil
+	}
+
+	if file == "" {
+		return t, nil
+	}
+	return loadFile(t, file)
+}
+
+// defaultFile returns the user theme location ($XDG_CONFIG_HOME/tablefy/theme.json on Linux)
+func defaultFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "tablefy", "theme.json")
+}
+
+// loadFile applies the colors defined in a JSON theme file on top of base
+func loadFile(base Theme, file string) (Theme, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Theme{}, fmt.Errorf("error reading theme file: %w", err)
+	}
+
+	var header struct {
+		Base string `json:"base"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return Theme{}, fmt.Errorf("error parsing theme file %s: %w", file, err)
+	}
+	if header.Base != "" {
+		preset, err := Get(header.Base)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme file %s: %w", file, err)
+		}
+		base = preset
+	}
+
+	// Unmarshal over the base so keys missing from the file keep the preset colors
+	t := base
+	if err := json.Unmarshal(data, &t); err != nil {
+		return Theme{}, fmt.Errorf("error parsing theme file %s: %w", file, err)
+	}
+	if t.Name == base.Name {
+		t.Name = filepath.Base(file)
+	}
+	return t, nil
+}
+
+// Monochrome reports whether the theme uses no colors at all
+func (t Theme) Monochrome() bool {
+	return t.Border == "" && t.Text == "" && t.Focus == "" && t.Selection == ""
+}
+
+// color converts a theme color to a lipgloss color, mapping "" to no color
+func color(c string) lipgloss.TerminalColor {
+	if c == "" {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(c)
+}
+
+// BorderStyle returns the style for table borders
+func (t Theme) BorderStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(color(t.Border))
+}
+
+// CellStyle returns the base style for a cell; header cells use the header color
+func (t Theme) CellStyle(header bool) lipgloss.Style {
+	if header {
+		return lipgloss.NewStyle().Foreground(color(t.Header))
+	}
+	return lipgloss.NewStyle().Foreground(color(t.Text))
+}
+
+// FocusStyle highlights the focused column, falling back to reverse video without colors
+func (t Theme) FocusStyle(style lipgloss.Style) lipgloss.Style {
+	if t.Focus == "" {
+		return style.Reverse(true)
+	}
+	return style.Background(color(t.Focus))
+}
+
+// SelectionStyle marks selected columns, falling back to underline without colors
+func (t Theme) SelectionStyle(style lipgloss.Style) lipgloss.Style {
+	if t.Selection == "" {
+		return style.Underline(true)
+	}
+	return style.Background(color(t.Selection))
+}
+
+// FilterHighlightStyle highlights the column being filtered, falling back to reverse video without colors
+func (t Theme) FilterHighlightStyle(style lipgloss.Style) lipgloss.Style {
+	if t.FilterHighlight == "" {
+		return style.Reverse(true)
+	}
+	return style.Background(color(t.FilterHighlight))
+}
+
+// FilterPromptStyle returns the style for the filter input line
+func (t Theme) FilterPromptStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(color(t.FilterPrompt)).Bold(true)
+}
+
+// IndicatorStyle returns the style for the filter badge
+func (t Theme) IndicatorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(color(t.Indicator)).Bold(true)
+}
+
+// HelpStyle returns the style for help and status text
+func (t Theme) HelpStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(color(t.Help))
+}
+
+// AddedStyle marks rows that appeared in the last refreshes, falling back to bold without colors
+func (t Theme) AddedStyle(style lipgloss.Style) lipgloss.Style {
+	if t.Added == "" {
+		return style.Bold(true)
+	}
+	return style.Foreground(color(t.Added)).Bold(true)
+}
+
+// ChangedStyle marks cells whose value changed in the last refreshes, falling back to underline without colors
+func (t Theme) ChangedStyle(style lipgloss.Style) lipgloss.Style {
+	if t.Changed == "" {
+		return style.Underline(true).Bold(true)
+	}
+	return style.Background(color(t.Changed)).Bold(true)
+}
+
+// RemovedStyle marks rows that disappeared in the last refreshes
+func (t Theme) RemovedStyle(style lipgloss.Style) lipgloss.Style {
+	style = style.Strikethrough(true)
+	if t.Removed == "" {
+		return style.Faint(true)
+	}
+	return style.Foreground(color(t.Removed))
+}
+
+// TitleStyle returns the style for view titles
+func (t Theme) TitleStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(color(t.Title)).Bold(true)
+}
@@ -0,0 +1,132 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDefault(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	th, err := Load("", "")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if th.Name != "dark" {
+		t.Errorf("Expected dark theme by default, got %q", th.Name)
+	}
+}
+
+func TestLoadHonorsNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	th, err := Load("", "")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !th.Monochrome() {
+		t.Errorf("Expected monochrome theme with NO_COLOR set, got %q", th.Name)
+	}
+
+	// An explicit theme still wins over NO_COLOR
+	th, err = Load("light", "")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if th.Name != "light" {
+		t.Errorf("Expected explicit light theme, got %q", th.Name)
+	}
+}
+
+func TestLoadFileOverridesNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	file := filepath.Join(t.TempDir(), "mine.json")
+	if err := os.WriteFile(file, []byte(`{"focus": "#FFD700"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	th, err := Load("", file)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if th.Monochrome() || th.Focus != "#FFD700" {
+		t.Errorf("Expected the theme file to win over NO_COLOR, got %+v", th)
+	}
+}
+
+func TestLoadUnknownTheme(t *testing.T) {
+	if _, err := Load("solarized", ""); err == nil {
+		t.Error("Expected error for unknown theme")
+	}
+}
+
+func TestLoadFileOverridesBase(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	file := filepath.Join(t.TempDir(), "mine.json")
+	content := `{"base": "light", "focus": "#FFD700"}`
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	th, err := Load("", file)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	light, _ := Get("light")
+	if th.Focus != "#FFD700" {
+		t.Errorf("Focus = %q, want #FFD700", th.Focus)
+	}
+	// Colors not set in the file come from the base preset
+	if th.Border != light.Border {
+		t.Errorf("Border = %q, want base color %q", th.Border, light.Border)
+	}
+	if th.Name != "mine.json" {
+		t.Errorf("Name = %q, want mine.json", th.Name)
+	}
+}
+
+func TestLoadFileFromConfigDir(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "tablefy"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tablefy", "theme.json"), []byte(`{"name": "custom", "border": "1"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	th, err := Load("", "")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if th.Name != "custom" || th.Border != "1" {
+		t.Errorf("Expected theme from config dir, got %+v", th)
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "broken.json")
+	if err := os.WriteFile(file, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load("dark", file); err == nil {
+		t.Error("Expected error for invalid theme file")
+	}
+}
+
+func TestMonochromeFallsBackToAttributes(t *testing.T) {
+	mono, _ := Get("monochrome")
+	style := mono.FocusStyle(mono.CellStyle(false))
+	if !style.GetReverse() {
+		t.Error("Monochrome focus should use reverse video")
+	}
+	style = mono.SelectionStyle(mono.CellStyle(false))
+	if !style.GetUnderline() {
+		t.Error("Monochrome selection should use underline")
+	}
+}
@@ -0,0 +1,64 @@
+package view
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+
+	"tablefy/internal/layout"
+	"tablefy/internal/model"
+)
+
+// newTable creates a table framed according to the model's border style and theme
+func newTable(m model.Model) *table.Table {
+	t := table.New().BorderStyle(m.Theme.BorderStyle())
+
+	switch m.Border {
+	case layout.BorderRounded:
+		t.Border(lipgloss.RoundedBorder())
+	case layout.BorderThick:
+		t.Border(lipgloss.ThickBorder())
+	case layout.BorderDouble:
+		t.Border(lipgloss.DoubleBorder())
+	case layout.BorderASCII:
+		t.Border(lipgloss.ASCIIBorder())
+	case layout.BorderUnderline:
+		// Keep only the line between header and data
+		t.Border(lipgloss.NormalBorder()).
+			BorderTop(false).
+			BorderBottom(false).
+			BorderLeft(false).
+			BorderRight(false).
+			BorderColumn(false)
+	case layout.BorderCompact:
+		t.Border(lipgloss.HiddenBorder()).
+			BorderTop(false).
+			BorderBottom(false).
+			BorderLeft(false).
+			BorderRight(false).
+			BorderColumn(false).
+			BorderHeader(false)
+	default:
+		t.Border(lipgloss.NormalBorder())
+	}
+
+	return t
+}
+
+// frameCell applies the padding of the border style to a cell style
+// Frameless styles separate columns with trailing spaces instead of border characters,
+// so the last column gets no padding at all
+func frameCell(style lipgloss.Style, border layout.BorderStyle, row, col, numCols int) lipgloss.Style {
+	frame := border.Frame()
+	if frame.Padding > 0 {
+		return style.Padding(0, frame.Padding/2)
+	}
+
+	if col < numCols-1 {
+		style = style.PaddingRight(frame.Separator)
+	}
+	// Without a header line, bold is what tells the header apart
+	if border == layout.BorderCompact && row == table.HeaderRow {
+		style = style.Bold(true)
+	}
+	return style
+}
@@ -0,0 +1,34 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"tablefy/internal/model"
+)
+
+// rowStyle marks the row under the cursor and the rows selected for actions
+// row comes from the table StyleFunc (header is -1)
+func rowStyle(style lipgloss.Style, m model.Model, pageRows []int, row int) lipgloss.Style {
+	if row < 0 || row >= len(pageRows) {
+		return style
+	}
+
+	rowIdx := pageRows[row]
+	if m.SelectedRows[rowIdx] {
+		style = m.Theme.SelectionStyle(style)
+	}
+	if current, ok := m.CurrentRowIndex(); ok && current == rowIdx {
+		style = m.Theme.FocusStyle(style).Bold(true)
+	}
+	return style
+}
+
+// buildRowStatus shows how many rows are selected for actions
+func buildRowStatus(m model.Model) string {
+	if len(m.SelectedRows) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" | [%d rows selected]", len(m.SelectedRows))
+}
@@ -0,0 +1,20 @@
+package view
+
+import (
+	"fmt"
+
+	"tablefy/internal/model"
+)
+
+// buildDiffStatus describes a table comparison for the help line: the counts per kind and the rows shown
+func buildDiffStatus(m model.Model) string {
+	if !m.Diffing() {
+		return ""
+	}
+
+	added, removed, changed := m.DiffCounts()
+	if added+removed+changed == 0 {
+		return " | [NO DIFFERENCES]"
+	}
+	return fmt.Sprintf(" | [DIFF +%d -%d ~%d, showing %s] d: Filter D: Export report", added, removed, changed, m.DiffFilter)
+}
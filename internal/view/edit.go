@@ -0,0 +1,83 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"tablefy/internal/model"
+)
+
+// RenderChangesView lists the edits made to the table in place of the table
+func RenderChangesView(m model.Model) string {
+	changes := m.EditChanges()
+	title := m.Theme.TitleStyle().Render(fmt.Sprintf("Changes: %s", describeChanges(changes)))
+
+	lines := m.ChangeLines()
+	if len(lines) == 0 {
+		lines = []string{"The table is as it was read"}
+	}
+	// Title, border and help take five lines
+	height := max(m.TermHeight-5, 1)
+	start := min(m.ChangesScroll, max(len(lines)-1, 0))
+	lines = lines[start:min(start+height, len(lines))]
+
+	width := max(m.TermWidth-4, 1)
+	for i, line := range lines {
+		style := lipgloss.NewStyle()
+		switch {
+		case strings.HasPrefix(line, "- "):
+			style = m.Theme.RemovedStyle(style)
+		case strings.HasPrefix(line, "~ "):
+			style = m.Theme.ChangedStyle(style)
+		}
+		lines[i] = style.Render(ansi.Truncate(line, width, "…"))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.Theme.BorderStyle().GetForeground()).
+		Padding(0, 1).
+		Render(title + "\n" + strings.Join(lines, "\n"))
+	help := m.Theme.HelpStyle().Render("↑↓/jk: Scroll | ~/Esc: Close")
+	return box + "\n" + help
+}
+
+// describeChanges summarizes the edits, e.g. "3 cells edited, 1 row deleted"
+func describeChanges(c model.EditChanges) string {
+	var parts []string
+	if c.Cells > 0 {
+		parts = append(parts, plural(c.Cells, "cell")+" edited")
+	}
+	if c.Rows > 0 {
+		parts = append(parts, plural(c.Rows, "row")+" deleted")
+	}
+	if c.Columns > 0 {
+		parts = append(parts, plural(c.Columns, "column")+" deleted")
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// plural writes a count with its noun, adding an s unless the count is one
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// buildEditStatus shows edit mode and its keys, or that the table has edits, for the help line
+func buildEditStatus(m model.Model) string {
+	changes := m.EditChanges()
+	switch {
+	case m.Editing:
+		return fmt.Sprintf(" | [EDIT: %s] Enter: Edit cell | D: Delete rows | C: Delete column | r: Revert cell | U: Revert all | ~: Changes | E/Esc: Done", describeChanges(changes))
+	case changes.Total() > 0:
+		return fmt.Sprintf(" | [EDITED: %s] ~: Changes | E: Edit", describeChanges(changes))
+	}
+	return ""
+}
@@ -25,36 +25,48 @@ func RenderFilterView(m model.Model) string {
 	filteredRows := GetFilteredRows(m.Rows, m.FilteredRowIndices)
 
 	// Calculate visible rows based on terminal height
-	visibleRows := layout.GetVisibleRows(m.TermHeight)
+	visibleRows := layout.GetVisibleRowsForFrame(m.TermHeight, m.Border.Frame())
 
 	// Apply scroll offset to filtered rows
 	displayRows := applyScrollOffset(filteredRows, m.FilterScrollOffset, visibleRows)
 
 	// Calculate optimal widths based on filtered rows
 	// This allows cells to fit better when you have a smaller subset of data
-	widths := layout.CalculateColumnWidths(filteredRows, m.TermWidth)
+	frame := m.Border.Frame()
+	widths := layout.CalculateColumnWidthsForFrame(filteredRows, m.TermWidth, frame)
 
 	// Apply auto-expand if enabled (use current column, not filter column)
 	if m.AutoExpand {
-		widths = layout.CalculateColumnWidthsWithAutoExpand(filteredRows, m.TermWidth, m.CurrentColumn, widths)
+		widths = layout.CalculateColumnWidthsWithAutoExpandForFrame(filteredRows, m.TermWidth, m.CurrentColumn, widths, frame)
 	}
 
 	// Truncate rows according to widths
-	truncatedRows := layout.TruncateRows(displayRows, widths)
+	truncatedRows := layout.TruncateRows(displayCells(m, true, m.FilterScrollOffset, visibleRows), widths)
+
+	// Model rows on this page, to look up refresh highlights
+	pageRows := pageRowIndices(m.FilteredRowIndices, m.FilterScrollOffset, visibleRows)
+
+	// Heatmap follows the live filter results
+	var heat heatmap
+	if m.Heatmap {
+		heat = newHeatmap(filteredRows, m.Theme)
+	}
 
 	// Create the table
-	t := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("#9D4EDD"))).
+	numCols := len(m.Rows[0])
+	t := newTable(m).
 		StyleFunc(func(row, col int) lipgloss.Style {
-			style := lipgloss.NewStyle().Padding(0, 1)
+			style := frameCell(m.Theme.CellStyle(row == table.HeaderRow), m.Border, row, col, numCols)
 
-			// Highlight current filter column with soft purple
+			// Highlight current filter column
 			if col == m.FilterColumnIndex {
-				style = style.Background(lipgloss.Color("#8B7BA8"))
+				style = m.Theme.FilterHighlightStyle(style)
 			}
 
-			return style.Foreground(lipgloss.Color("252"))
+			if m.Heatmap {
+				style = heat.apply(style, displayRows, row, col)
+			}
+			return changeStyle(style, m, pageRows, row, col)
 		})
 
 	// Add all rows
@@ -67,18 +79,14 @@ func RenderFilterView(m model.Model) string {
 	matchCount := len(m.FilteredRowIndices)
 	filterInput := fmt.Sprintf("Filter [%s]: %s (%d matches)", columnName, m.FilterInput, matchCount)
 
-	filterStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("220")).
-		Bold(true).
+	filterStyle := m.Theme.FilterPromptStyle().
 		Padding(0, 1)
 
 	filterDisplay := filterStyle.Render(filterInput)
 
 	// Help text
 	helpText := "Type to search | ↑↓/jk/PgUp/PgDn: Scroll | Esc: Cancel | Enter: Apply"
-	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
-		Render(helpText)
+	help := m.Theme.HelpStyle().Render(helpText)
 
 	return t.Render() + "\n" + filterDisplay + "\n" + help
 }
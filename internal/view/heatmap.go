@@ -0,0 +1,87 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"tablefy/internal/model"
+	"tablefy/internal/theme"
+)
+
+// heatmap256 is a green → yellow → red ramp from the xterm 256-color palette
+var heatmap256 = []string{"46", "82", "118", "154", "190", "226", "220", "214", "208", "202", "196"}
+
+// heatmap16 uses the basic ANSI green, yellow and red for terminals limited to 16 colors
+var heatmap16 = []string{"2", "3", "1"}
+
+// heatmap colors numeric cells according to their position within the column range
+type heatmap struct {
+	ranges  []model.NumericRange
+	profile termenv.Profile
+}
+
+// newHeatmap computes the numeric ranges over the displayed rows (header included at index 0)
+func newHeatmap(rows [][]string, t theme.Theme) heatmap {
+	profile := lipgloss.ColorProfile()
+	if t.Monochrome() {
+		// Monochrome themes (and NO_COLOR) never add color
+		profile = termenv.Ascii
+	}
+	return heatmap{
+		ranges:  model.ColumnNumericRanges(rows),
+		profile: profile,
+	}
+}
+
+// color returns the gradient color for a cell, or false if the cell should not be colored
+func (h heatmap) color(col int, cell string) (lipgloss.TerminalColor, bool) {
+	if col < 0 || col >= len(h.ranges) || !h.ranges[col].Valid {
+		return nil, false
+	}
+	value, ok := model.NumericValue(cell)
+	if !ok {
+		return nil, false
+	}
+	pos := h.ranges[col].Position(value)
+
+	// Pick the palette according to what the terminal can display
+	switch h.profile {
+	case termenv.TrueColor:
+		return lipgloss.Color(gradientHex(pos)), true
+	case termenv.ANSI256:
+		return lipgloss.Color(heatmap256[int(pos*float64(len(heatmap256)-1)+0.5)]), true
+	case termenv.ANSI:
+		return lipgloss.Color(heatmap16[int(pos*float64(len(heatmap16)-1)+0.5)]), true
+	default:
+		// No color support: leave cells untouched
+		return nil, false
+	}
+}
+
+// gradientHex interpolates green (0) → yellow (0.5) → red (1) as a hex color
+func gradientHex(pos float64) string {
+	var r, g float64
+	if pos < 0.5 {
+		r = 255 * pos * 2
+		g = 200
+	} else {
+		r = 255
+		g = 200 * (1 - pos) * 2
+	}
+	return fmt.Sprintf("#%02X%02X%02X", int(r), int(g), 60)
+}
+
+// apply sets the gradient foreground on style for data cells of numeric columns
+func (h heatmap) apply(style lipgloss.Style, rows [][]string, row, col int) lipgloss.Style {
+	// row comes from the table StyleFunc: header is -1, data rows start at 0
+	dataRow := row + 1
+	if row < 0 || dataRow >= len(rows) || col >= len(rows[dataRow]) {
+		return style
+	}
+	if color, ok := h.color(col, rows[dataRow][col]); ok {
+		style = style.Foreground(color).Bold(true)
+	}
+	return style
+}
@@ -12,6 +12,9 @@ import (
 // RenderNormalView renders the table with all columns
 func RenderNormalView(m model.Model) string {
 	if len(m.Rows) == 0 {
+		if m.StreamOpen {
+			return m.Theme.HelpStyle().Render("Waiting for input" + buildStreamStatus(m) + " | q: Quit")
+		}
 		return "No data to display"
 	}
 
@@ -21,42 +24,58 @@ func RenderNormalView(m model.Model) string {
 		rowsToDisplay = GetFilteredRows(m.Rows, m.FilteredRowIndices)
 	}
 
+	// Rows removed by the last refreshes follow the table, but don't shape its widths and heatmap
+	shownRows, shownIndices := withGhosts(m, rowsToDisplay, m.DataRowIndices())
+
 	// Calculate visible rows based on terminal height
-	visibleRows := layout.GetVisibleRows(m.TermHeight)
+	visibleRows := layout.GetVisibleRowsForFrame(m.TermHeight, m.Border.Frame())
 
 	// Apply scroll offset to get visible subset of rows
-	displayRows := applyScrollOffset(rowsToDisplay, m.ScrollOffset, visibleRows)
+	displayRows := applyScrollOffset(shownRows, m.ScrollOffset, visibleRows)
 
 	// Calculate optimal widths
 	// Base widths on the rows that will be displayed (filtered or unfiltered)
-	widths := layout.CalculateColumnWidths(rowsToDisplay, m.TermWidth)
+	frame := m.Border.Frame()
+	widths := layout.CalculateColumnWidthsForFrame(rowsToDisplay, m.TermWidth, frame)
 
 	// Apply auto-expand if enabled
 	if m.AutoExpand {
-		widths = layout.CalculateColumnWidthsWithAutoExpand(rowsToDisplay, m.TermWidth, m.CurrentColumn, widths)
+		widths = layout.CalculateColumnWidthsWithAutoExpandForFrame(rowsToDisplay, m.TermWidth, m.CurrentColumn, widths, frame)
 	}
 
 	// Truncate rows according to widths
-	truncatedRows := layout.TruncateRows(displayRows, widths)
+	truncatedRows := layout.TruncateRows(displayCells(m, len(m.FilteredRowIndices) > 0, m.ScrollOffset, visibleRows), widths)
+
+	// Model rows on this page, to look up refresh highlights
+	pageRows := pageRowIndices(shownIndices, m.ScrollOffset, visibleRows)
+
+	// Heatmap ranges span every displayed row, not just the visible page
+	var heat heatmap
+	if m.Heatmap {
+		heat = newHeatmap(rowsToDisplay, m.Theme)
+	}
 
 	// Create a new table
-	t := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("#9D4EDD"))).
+	numCols := len(m.Rows[0])
+	t := newTable(m).
 		StyleFunc(func(row, col int) lipgloss.Style {
-			style := lipgloss.NewStyle().Padding(0, 1)
+			style := frameCell(m.Theme.CellStyle(row == table.HeaderRow), m.Border, row, col, numCols)
 
 			// Highlight current column
 			if col == m.CurrentColumn {
-				style = style.Background(lipgloss.Color("#3D3D3D"))
+				style = m.Theme.FocusStyle(style)
 			}
 
 			// Mark selected columns with different background
 			if m.SelectedColumns[col] {
-				style = style.Background(lipgloss.Color("#5A4E8C"))
+				style = m.Theme.SelectionStyle(style)
 			}
+			style = rowStyle(style, m, pageRows, row)
 
-			return style.Foreground(lipgloss.Color("252"))
+			if m.Heatmap {
+				style = heat.apply(style, displayRows, row, col)
+			}
+			return changeStyle(style, m, pageRows, row, col)
 		})
 
 	// Add all rows
@@ -73,10 +92,8 @@ func RenderNormalView(m model.Model) string {
 	}
 
 	// Build help text with scroll indicator
-	helpText := buildNormalViewHelp(m, rowsToDisplay, visibleRows)
-	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
-		Render(helpText)
+	helpText := buildNormalViewHelp(m, shownRows, visibleRows)
+	help := m.Theme.HelpStyle().Render(helpText)
 
 	return output + "\n" + help
 }
@@ -90,9 +107,7 @@ func buildFilterIndicator(m model.Model) string {
 
 	filterText := fmt.Sprintf("🔍 Filter active: [%s] = \"%s\" (%d results)", columnName, m.FilterInput, len(m.FilteredRowIndices))
 
-	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("11")). // Bright yellow
-		Bold(true).
+	return m.Theme.IndicatorStyle().
 		Padding(0, 1).
 		Render(filterText)
 }
@@ -113,10 +128,15 @@ func buildNormalViewHelp(m model.Model, rowsToDisplay [][]string, visibleRows in
 		autoExpandInfo = " | [AUTO-EXPAND ON]"
 	}
 
+	heatmapInfo := ""
+	if m.Heatmap {
+		heatmapInfo = " | [HEATMAP ON]"
+	}
+
 	filterInfo := ""
 	if len(m.FilteredRowIndices) > 0 {
 		filterInfo = fmt.Sprintf(" | [FILTERED: %d/%d rows]", totalDataRows, len(m.Rows)-1)
 	}
 
-	return fmt.Sprintf("← → / h l: Navigate | s: Toggle select (%d selected) | Enter: Zoom | f: Filter%s%s%s | q: Quit", selectedCount, scrollInfo, autoExpandInfo, filterInfo)
+	return fmt.Sprintf("← → / h l: Navigate | s: Toggle select (%d selected) | Enter: Zoom | f: Filter | m: Heatmap%s%s%s%s%s | q: Quit", selectedCount, scrollInfo, autoExpandInfo, heatmapInfo, filterInfo, buildRowStatus(m)+buildWatchStatus(m)+buildStreamStatus(m)+buildDiffStatus(m)+buildPipeStatus(m)+buildEditStatus(m))
 }
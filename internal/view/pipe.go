@@ -0,0 +1,16 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"tablefy/internal/model"
+)
+
+// buildPipeStatus shows the commands the table was piped through for the help line
+func buildPipeStatus(m model.Model) string {
+	if len(m.PipeCommands) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" | [PIPED: %s] u: Back", strings.Join(m.PipeCommands, " | "))
+}
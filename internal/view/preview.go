@@ -0,0 +1,74 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"tablefy/internal/model"
+)
+
+// renderWithPreview places the preview pane below or beside the rendered table
+func renderWithPreview(m model.Model, output string) string {
+	if !m.Previewing() {
+		return output
+	}
+	pane := renderPreview(m)
+	if m.PreviewRight {
+		// Cut the table's lines at its width rather than wrapping them under the pane
+		lines := strings.Split(output, "\n")
+		for i, line := range lines {
+			lines[i] = ansi.Truncate(line, m.TermWidth, "")
+		}
+		table := lipgloss.NewStyle().Width(m.TermWidth).Render(strings.Join(lines, "\n"))
+		return lipgloss.JoinHorizontal(lipgloss.Top, table, pane)
+	}
+	return output + "\n" + pane
+}
+
+// renderPreview renders the preview command's output in a bordered pane of the preview size
+func renderPreview(m model.Model) string {
+	width, height := m.PreviewSize()
+	// The border takes two columns and two lines, the title one more line
+	innerWidth, innerHeight := max(width-2, 1), max(height-3, 1)
+
+	title := m.Theme.TitleStyle().Render(ansi.Truncate(buildPreviewTitle(m), innerWidth, "…"))
+
+	lines := strings.Split(strings.TrimRight(m.PreviewOutput, "\n"), "\n")
+	if m.PreviewOutput == "" {
+		lines = nil
+	}
+	start := min(m.PreviewScroll, max(len(lines)-1, 0))
+	lines = lines[start:]
+	if len(lines) > innerHeight {
+		lines = lines[:innerHeight]
+	}
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, innerWidth, "…")
+	}
+
+	body := title + "\n" + strings.Join(lines, "\n")
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.Theme.BorderStyle().GetForeground()).
+		Width(innerWidth).
+		Height(innerHeight + 1).
+		MaxHeight(height).
+		Render(body)
+}
+
+// buildPreviewTitle describes the preview command and how its run went
+func buildPreviewTitle(m model.Model) string {
+	title := "Preview: " + m.PreviewCommand
+	switch {
+	case m.PreviewRunning:
+		title += " [RUNNING]"
+	case m.PreviewErr != nil:
+		title += fmt.Sprintf(" [%v]", m.PreviewErr)
+	}
+	if m.PreviewScroll > 0 {
+		title += fmt.Sprintf(" (line %d)", m.PreviewScroll+1)
+	}
+	return title + " | P: Hide | Shift+↑↓: Scroll"
+}
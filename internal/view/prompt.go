@@ -0,0 +1,36 @@
+package view
+
+import (
+	"strings"
+
+	"tablefy/internal/model"
+)
+
+// renderBottomLine replaces the help line with the open prompt or the last status message
+func renderBottomLine(m model.Model, output string) string {
+	var line string
+	switch {
+	case m.Prompt != nil:
+		line = buildPromptLine(m)
+	case m.StatusMessage != "":
+		line = m.Theme.IndicatorStyle().Padding(0, 1).Render(m.StatusMessage)
+	default:
+		return output
+	}
+
+	if i := strings.LastIndex(output, "\n"); i >= 0 {
+		return output[:i+1] + line
+	}
+	return line
+}
+
+// buildPromptLine renders the prompt question, its input and the expected answer
+func buildPromptLine(m model.Model) string {
+	text := m.Prompt.Label + ": " + m.Prompt.Input
+	if m.Prompt.Confirm {
+		text = m.Prompt.Label
+	}
+	prompt := m.Theme.FilterPromptStyle().Padding(0, 1).Render(text)
+	hint := m.Theme.HelpStyle().Render(m.Prompt.Hint + " | Enter: Confirm | Esc: Cancel")
+	return prompt + " " + hint
+}
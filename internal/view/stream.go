@@ -0,0 +1,69 @@
+package view
+
+import (
+	"fmt"
+	"time"
+
+	"tablefy/internal/model"
+)
+
+// spinnerFrames animate the loading indicator
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// buildStreamStatus describes the input for the help line: loading progress, or the state of a live stream
+func buildStreamStatus(m model.Model) string {
+	if !m.Streaming() {
+		return ""
+	}
+
+	rows := max(len(m.Rows)-1, 0)
+	var status string
+	switch {
+	case m.LiveStream:
+		state := "STREAM CLOSED"
+		if m.StreamOpen {
+			state = "STREAM OPEN"
+		}
+		if m.Follow {
+			state += " FOLLOW"
+		}
+		status = fmt.Sprintf(" | [%s: %d rows] G: Follow", state, rows)
+	case m.StreamOpen:
+		status = fmt.Sprintf(" | %s Loading %d rows (%s) Ctrl+C: Stop", spinnerFrames[m.SpinnerFrame%len(spinnerFrames)], rows, formatThroughput(rows, m.LoadedBytes, time.Since(m.LoadStart)))
+	}
+	if m.LoadCancelled {
+		status += fmt.Sprintf(" | [LOADING STOPPED at %d rows]", rows)
+	}
+
+	if m.MalformedLines > 0 {
+		status += fmt.Sprintf(" | [%d malformed lines sanitized, first at line %d]", m.MalformedLines, m.FirstMalformedLine)
+	}
+
+	if m.StreamErr != nil {
+		status += fmt.Sprintf(" | Read failed: %v", m.StreamErr)
+	}
+	return status
+}
+
+// formatThroughput describes the loading speed in rows and bytes per second
+func formatThroughput(rows int, bytes int64, elapsed time.Duration) string {
+	seconds := elapsed.Seconds()
+	if seconds <= 0 {
+		return formatBytes(float64(bytes))
+	}
+	return fmt.Sprintf("%s, %.0f rows/s, %s/s", formatBytes(float64(bytes)), float64(rows)/seconds, formatBytes(float64(bytes)/seconds))
+}
+
+// formatBytes formats a byte count with a binary unit
+func formatBytes(n float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	unit := 0
+	for n >= 1024 && unit < len(units)-1 {
+		n /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%.0f %s", n, units[unit])
+	}
+	return fmt.Sprintf("%.1f %s", n, units[unit])
+}
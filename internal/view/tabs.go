@@ -0,0 +1,33 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"tablefy/internal/model"
+)
+
+// RenderTabs renders the tab bar above the active tab
+func RenderTabs(t model.TabSet) string {
+	return buildTabBar(t) + "\n" + Render(t.Current())
+}
+
+// buildTabBar lists the tabs with their number, name and row count; the active tab is highlighted
+func buildTabBar(t model.TabSet) string {
+	theme := t.Current().Theme
+	var labels []string
+	for i, tab := range t.Tabs {
+		count := fmt.Sprintf("%d", max(len(tab.Model.Rows)-1, 0))
+		if tab.Model.StreamOpen {
+			count += "…"
+		}
+		label := fmt.Sprintf(" %d %s (%s) ", i+1, tab.Name, count)
+		if i == t.Active {
+			label = theme.FocusStyle(theme.TitleStyle()).Render(label)
+		} else {
+			label = theme.HelpStyle().Render(label)
+		}
+		labels = append(labels, label)
+	}
+	return strings.Join(labels, theme.BorderStyle().Render("│")) + theme.HelpStyle().Render("  gt/gT/1-9: Switch tab")
+}
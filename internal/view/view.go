@@ -7,13 +7,18 @@ import (
 
 // Render renders the UI based on the current view mode
 func Render(m model.Model) string {
-	if m.ViewMode == model.FilterView {
-		return RenderFilterView(m)
+	var output string
+	switch {
+	case m.ShowChanges:
+		output = RenderChangesView(m)
+	case m.ViewMode == model.FilterView:
+		output = RenderFilterView(m)
+	case m.ViewMode == model.ZoomView:
+		output = RenderZoomView(m)
+	default:
+		output = RenderNormalView(m)
 	}
-	if m.ViewMode == model.ZoomView {
-		return RenderZoomView(m)
-	}
-	return RenderNormalView(m)
+	return renderWithPreview(m, renderBottomLine(m, output))
 }
 
 // applyScrollOffset applies scroll offset to rows
@@ -31,6 +36,17 @@ func applyScrollOffset(rows [][]string, scrollOffset, visibleRows int) [][]strin
 	return displayRows
 }
 
+// displayCells returns the cells drawn for a page: the same rows as the plain display rows,
+// taken from the styled input when input colors are kept
+func displayCells(m model.Model, filtered bool, scrollOffset, visibleRows int) [][]string {
+	cells := m.CellRows()
+	if filtered {
+		cells = GetFilteredRows(cells, m.FilteredRowIndices)
+	}
+	cells, _ = withGhosts(m, cells, nil)
+	return applyScrollOffset(cells, scrollOffset, visibleRows)
+}
+
 // GetFilteredRows extracts rows at specified indices (wrapper for model function)
 func GetFilteredRows(rows [][]string, filteredIndices []int) [][]string {
 	return model.GetFilteredRows(rows, filteredIndices)
@@ -71,12 +87,12 @@ func extractSelectedColumns(rows [][]string, selectedIndices []int) [][]string {
 }
 
 // calculateZoomWidths calculates optimal widths for zoomed table
-func calculateZoomWidths(zoomedRows [][]string, termWidth int) []int {
+func calculateZoomWidths(zoomedRows [][]string, termWidth int, frame layout.Frame) []int {
 	// First, try to use full widths without truncation
 	widths := layout.CalculateFullColumnWidths(zoomedRows)
 
 	// Check if the table fits in the terminal
-	overhead := len(widths)*3 + 1 // borders and padding
+	overhead := frame.Overhead(len(widths)) // borders and padding
 	totalWidth := overhead
 	for _, w := range widths {
 		totalWidth += w
@@ -84,7 +100,7 @@ func calculateZoomWidths(zoomedRows [][]string, termWidth int) []int {
 
 	// If it doesn't fit, recalculate with terminal width constraint
 	if totalWidth > termWidth {
-		widths = layout.CalculateColumnWidths(zoomedRows, termWidth)
+		widths = layout.CalculateColumnWidthsForFrame(zoomedRows, termWidth, frame)
 	}
 
 	return widths
@@ -0,0 +1,98 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"tablefy/internal/model"
+)
+
+// buildWatchStatus describes watch mode for the help line: interval, last refresh time and pause state
+func buildWatchStatus(m model.Model) string {
+	if !m.Watching() {
+		return ""
+	}
+
+	state := fmt.Sprintf("WATCH %s", m.WatchInterval)
+	if m.WatchPaused {
+		state += " PAUSED"
+	}
+	status := fmt.Sprintf(" | [%s @ %s] p: Pause/Resume r: Refresh", state, m.LastRefresh.Format("15:04:05"))
+	// With a preview, P toggles the pane instead of pausing
+	if m.PreviewCommand != "" {
+		status += " P: Preview"
+	}
+	status += buildHistoryStatus(m)
+
+	if m.RefreshErr != nil {
+		status += fmt.Sprintf(" | Refresh failed: %v", m.RefreshErr)
+	}
+	return status
+}
+
+// buildHistoryStatus describes the snapshot timeline: which snapshot is shown, when it was taken and the diff base
+func buildHistoryStatus(m model.Model) string {
+	position, total := m.ShownSnapshot()
+	if total < 2 {
+		return ""
+	}
+
+	status := " | [: History"
+	if m.Browsing() {
+		status = fmt.Sprintf(" | [SNAPSHOT %d/%d @ %s] [ ]: Step", position, total, m.ShownSnapshotTime().Format("15:04:05"))
+	}
+	if !m.DiffBase.IsZero() {
+		status += fmt.Sprintf(" =: Base %s", m.DiffBase.Format("15:04:05"))
+	} else {
+		status += " =: Mark base"
+	}
+	return status + " D: Export diff"
+}
+
+// pageRowIndices returns the model row indices of the data rows on a page, aligned with applyScrollOffset
+func pageRowIndices(indices []int, scrollOffset, visibleRows int) []int {
+	start := min(scrollOffset, len(indices))
+	end := min(start+visibleRows, len(indices))
+	return indices[start:end]
+}
+
+// ghostRow stands for a removed row in page row indices: ghosts are not part of the model rows
+const ghostRow = -1
+
+// withGhosts appends the rows removed by the last refreshes after the table rows, when the model shows them
+// indices gets ghostRow for each of them, so indices stay aligned with rows
+func withGhosts(m model.Model, rows [][]string, indices []int) ([][]string, []int) {
+	if !m.GhostsShown() {
+		return rows, indices
+	}
+	rows = append(append([][]string(nil), rows...), m.Ghosts...)
+	indices = append([]int(nil), indices...)
+	for range m.Ghosts {
+		indices = append(indices, ghostRow)
+	}
+	return rows, indices
+}
+
+// changeStyle applies the refresh highlights to a data cell: added rows, removed (ghost) rows and changed cells
+// row comes from the table StyleFunc (header is -1) and col is the column index in the model
+func changeStyle(style lipgloss.Style, m model.Model, pageRows []int, row, col int) lipgloss.Style {
+	if row < 0 || row >= len(pageRows) {
+		return style
+	}
+
+	rowIdx := pageRows[row]
+	if rowIdx == ghostRow {
+		return m.Theme.RemovedStyle(style)
+	}
+	switch m.RowStateAt(rowIdx) {
+	case model.RowRemoved:
+		return m.Theme.RemovedStyle(style)
+	case model.RowAdded:
+		return m.Theme.AddedStyle(style)
+	}
+	if m.CellChanged(rowIdx, col) {
+		return m.Theme.ChangedStyle(style)
+	}
+	return style
+}
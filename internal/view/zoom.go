@@ -25,29 +25,52 @@ func RenderZoomView(m model.Model) string {
 	// Get sorted list of selected column indices
 	selectedIndices := sortSelectedColumns(m.SelectedColumns)
 
-	// Extract selected columns
-	zoomedRows := extractSelectedColumns(rowsToUse, selectedIndices)
+	// Extract selected columns; rows removed by the last refreshes follow the table
+	measureRows := extractSelectedColumns(rowsToUse, selectedIndices)
+	shownRows, shownIndices := withGhosts(m, rowsToUse, m.DataRowIndices())
+	zoomedRows := extractSelectedColumns(shownRows, selectedIndices)
 
 	// Calculate visible rows based on terminal height (account for title and help)
-	visibleRows := layout.GetVisibleRowsForZoom(m.TermHeight)
+	visibleRows := layout.GetVisibleRowsForZoomFrame(m.TermHeight, m.Border.Frame())
 
 	// Apply scroll offset to get visible subset of rows
 	displayRows := applyScrollOffset(zoomedRows, m.ScrollOffset, visibleRows)
 
-	// Calculate optimal widths for zoomed table
-	widths := calculateZoomWidths(zoomedRows, m.TermWidth)
+	// Calculate optimal widths for zoomed table, without removed rows
+	widths := calculateZoomWidths(measureRows, m.TermWidth, m.Border.Frame())
 
 	// Truncate only the display rows according to widths
-	truncatedRows := layout.TruncateRows(displayRows, widths)
+	// Cells are drawn from the styled input when input colors are kept
+	cellsToUse := m.CellRows()
+	if len(m.FilteredRowIndices) > 0 {
+		cellsToUse = GetFilteredRows(cellsToUse, m.FilteredRowIndices)
+	}
+	cellsToUse, _ = withGhosts(m, cellsToUse, nil)
+	displayCells := applyScrollOffset(extractSelectedColumns(cellsToUse, selectedIndices), m.ScrollOffset, visibleRows)
+	truncatedRows := layout.TruncateRows(displayCells, widths)
+
+	// Model rows on this page, to look up refresh highlights
+	pageRows := pageRowIndices(shownIndices, m.ScrollOffset, visibleRows)
+
+	// Heatmap ranges are computed on the zoomed columns
+	var heat heatmap
+	if m.Heatmap {
+		heat = newHeatmap(measureRows, m.Theme)
+	}
 
 	// Create table
-	t := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("#9D4EDD"))).
+	numCols := len(selectedIndices)
+	t := newTable(m).
 		StyleFunc(func(row, col int) lipgloss.Style {
-			return lipgloss.NewStyle().
-				Foreground(lipgloss.Color("252")).
-				Padding(0, 1)
+			style := frameCell(m.Theme.CellStyle(row == table.HeaderRow), m.Border, row, col, numCols)
+			style = rowStyle(style, m, pageRows, row)
+			if m.Heatmap {
+				style = heat.apply(style, displayRows, row, col)
+			}
+			if col < len(selectedIndices) {
+				style = changeStyle(style, m, pageRows, row, selectedIndices[col])
+			}
+			return style
 		})
 
 	// Add header and rows
@@ -78,9 +101,7 @@ func RenderZoomView(m model.Model) string {
 
 	// Build help text with scroll indicator
 	helpText := buildZoomViewHelp(m, zoomedRows, visibleRows)
-	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
-		Render(helpText)
+	help := m.Theme.HelpStyle().Render(helpText)
 
 	return fmt.Sprintf("%s\n\n%s\n%s", title, output, help)
 }
@@ -94,9 +115,7 @@ func buildZoomTitle(m model.Model, selectedIndices []int) string {
 		}
 	}
 
-	return lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#9D4EDD")).
+	return m.Theme.TitleStyle().
 		Render(fmt.Sprintf("Zoomed: %s", strings.Join(columnNames, ", ")))
 }
 
@@ -109,5 +128,10 @@ func buildZoomViewHelp(m model.Model, zoomedRows [][]string, visibleRows int) st
 		maxPos := totalDataRows - visibleRows + 1
 		scrollInfo = fmt.Sprintf(" | ↑↓/jk/PgUp/PgDn: Scroll (%d/%d)", currentPos, maxPos)
 	}
-	return fmt.Sprintf("q: Exit zoom%s", scrollInfo)
+	heatmapInfo := ""
+	if m.Heatmap {
+		heatmapInfo = " | [HEATMAP ON]"
+	}
+
+	return fmt.Sprintf("q: Exit zoom | m: Heatmap%s%s%s", scrollInfo, heatmapInfo, buildWatchStatus(m))
 }